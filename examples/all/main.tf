@@ -93,13 +93,13 @@ resource "leostream_pool" "awspool" {
       aws_iam_name  = var.aws_iam_name
       aws_sub_net   = var.aws_sub_net
     }
-    provision_on_off             = 0
+    provision_on_off             = false
     provision_max                = 0
     provision_threshold          = 0
     provision_vm_display_name    = "Image name:'emr 5.23.0-ami-roller-7 hvm ebs' SBOM:http://blog.joustie.nl"
     provision_vm_name_next_value = 8
     provision_vm_id              = element([for image in data.leostream_center_ds.center_ds.images : image if "${image.name}" == var.pool_image_name], 0).id
-    mark_deletable               = 1
+    mark_deletable               = true
   }
 
 }
@@ -0,0 +1,13 @@
+# Copyright (c) HashiCorp, Inc.
+
+# Opens a session with the credentials of the provider. The token is never
+# stored in the plan or state, the session ends when it times out on the broker.
+ephemeral "leostream_session" "session" {}
+
+# Pass the token to a provider or tool that calls the Leostream REST API itself
+provider "restapi" {
+  uri = "https://leostream.example.com/rest/v1"
+  headers = {
+    Authorization = ephemeral.leostream_session.session.token
+  }
+}
@@ -0,0 +1,10 @@
+# Copyright (c) HashiCorp, Inc.
+
+variable "pool_import_id" {
+  type    = string
+  default = "name:AWS desktop pool 2"
+}
+
+output "pool_import_name" {
+  value = provider::leostream::parse_import_id(var.pool_import_id).name
+}
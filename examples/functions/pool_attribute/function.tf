@@ -0,0 +1,13 @@
+# Copyright (c) HashiCorp, Inc.
+
+resource "leostream_basic_pool" "sales" {
+  name = "Sales desktops"
+
+  pool_definition = {
+    restrict_by = "A"
+    attributes = [
+      provider::leostream::pool_attribute("name", "bw", "sales-"),
+      provider::leostream::pool_attribute("ip", "ip", "10.20.0.0/16"),
+    ]
+  }
+}
@@ -0,0 +1,9 @@
+# Copyright (c) HashiCorp, Inc.
+
+resource "leostream_basic_pool" "desktops" {
+  name = "Basic desktop pool 1"
+
+  provision = {
+    provision_vm_name = provider::leostream::vm_name("desktop-", "")
+  }
+}
@@ -1,5 +1,10 @@
 # Copyright (c) HashiCorp, Inc.
 
-# Order can be imported by specifying the numeric identifier.
+# A pool can be imported by specifying the numeric identifier.
 
 terraform import leostream_aws_pool 123
+
+# Or by its name, prefixed with "name:". The name must match exactly one AWS pool,
+# pools of other types with the same name are ignored.
+
+terraform import leostream_aws_pool "name:AWS desktop pool 2"
@@ -26,12 +26,12 @@ resource "leostream_aws_pool" "pool_1" {
       type = "amazon"
       id   = 51
     }
-    provision_on_off             = 0
+    provision_on_off             = false
     provision_max                = 0
     provision_threshold          = 0
     provision_vm_display_name    = "aws-desktop-1"
     provision_vm_name_next_value = 8
     provision_vm_id              = 15
-    mark_deletable               = 1
+    mark_deletable               = true
   }
 }
@@ -21,12 +21,12 @@ resource "leostream_basic_pool" "pool_1" {
   provision = {
     provision_server_id          = 51
     provision_vm_name            = "desktop-{SEQUENCE}"
-    provision_on_off             = 0
+    provision_on_off             = false
     provision_max                = 0
     provision_threshold          = 0
     provision_vm_display_name    = "vm 1"
     provision_vm_name_next_value = 0
     provision_vm_id              = 15
-    mark_deletable               = 1
+    mark_deletable               = true
   }
 }
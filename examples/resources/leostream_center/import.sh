@@ -1,5 +1,14 @@
 # Copyright (c) HashiCorp, Inc.
 
-# Order can be imported by specifying the numeric identifier.
+# A center can be imported by specifying the numeric identifier.
 
 terraform import leostream_center 123
+
+# Or by its name, prefixed with "name:". The name must match exactly one center.
+
+terraform import leostream_center "name:AWS us-east-1"
+
+# The broker does not return vc_password, so it is empty after import. The first
+# apply after import updates the center once to store the configured password.
+# State written by provider versions that stored the "**********" mask behaves
+# the same way after upgrading.
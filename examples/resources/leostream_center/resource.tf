@@ -9,7 +9,7 @@ resource "leostream_center" "awscenter" {
     vc_name               = "aws_access key"
     vc_password           = "aws_secret key"
     vc_auth_method        = "access_key"
-    wait_inst_status      = 1
-    wait_sys_status       = 1
+    wait_inst_status      = true
+    wait_sys_status       = true
   }
 }
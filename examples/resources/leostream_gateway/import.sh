@@ -1,5 +1,9 @@
 # Copyright (c) HashiCorp, Inc.
 
-# Order can be imported by specifying the numeric identifier.
+# A gateway can be imported by specifying the numeric identifier.
 
 terraform import leostream_gateway 123
+
+# Or by its name, prefixed with "name:". The name must match exactly one gateway.
+
+terraform import leostream_gateway "name:gateway_us_east_1"
@@ -0,0 +1,697 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testBrokerAPIPath is the path of the Leostream REST API on the broker.
+const testBrokerAPIPath = "/rest/v1"
+
+// testBroker is an in-memory stand-in for the REST API of a Leostream broker.
+// It implements the endpoints used by the Leostream client: session login and
+// the list, get, create, update and delete endpoints of pools, centers and
+// gateways. Objects are stored as the JSON sent by the client, so every field
+// the client writes is returned the way a broker would return it.
+//
+// Like a broker, it requires a session for every request, allocates the IDs of
+// new objects, returns stored secrets masked and answers unknown IDs with a 404.
+// Misbehaviour of a broker is scripted with inject, expireSessions and remove.
+type testBroker struct {
+	server *httptest.Server
+
+	username string
+	password string
+
+	mu       sync.Mutex
+	sessions map[string]bool
+	objects  map[string]map[int64]map[string]any
+	nextID   map[string]int64
+	logins   int
+	faults   []*brokerFault
+	requests []string
+}
+
+// brokerFault makes the test broker misbehave on the requests it matches.
+type brokerFault struct {
+	// method and path select the requests, e.g. "PUT" and "pools/1". An empty
+	// method or path matches every request, the path excludes testBrokerAPIPath.
+	method string
+	path   string
+
+	// times is the number of requests the fault applies to, 0 for every request
+	times int
+
+	// delay holds the response, to simulate a slow broker
+	delay time.Duration
+
+	// status is returned instead of handling the request, e.g. a 500
+	status int
+
+	// expireSession ends all sessions and returns a 401, as if the session timed out
+	expireSession bool
+
+	// ignore lists the fields of an update that are not stored, in dotted
+	// notation like "center_definition.notes", to simulate a partially applied update
+	ignore []string
+}
+
+// inject adds a fault, the first injected fault matching a request applies.
+func (b *testBroker) inject(fault brokerFault) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.faults = append(b.faults, &fault)
+}
+
+// expireSessions ends all sessions, the next request has to log in again.
+func (b *testBroker) expireSessions() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sessions = map[string]bool{}
+}
+
+// remove deletes an object outside Terraform.
+func (b *testBroker) remove(collection string, id int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.objects[collection], id)
+}
+
+// requestCount returns the number of requests with the method and path, e.g. "PUT" and "pools/1".
+func (b *testBroker) requestCount(method string, path string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := 0
+	for _, request := range b.requests {
+		if request == method+" "+path {
+			count++
+		}
+	}
+	return count
+}
+
+// fault returns the fault for a request and counts it as applied, nil when no fault matches.
+func (b *testBroker) fault(method string, path string) *brokerFault {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.requests = append(b.requests, method+" "+path)
+
+	for i, fault := range b.faults {
+		if (fault.method != "" && fault.method != method) || (fault.path != "" && fault.path != path) {
+			continue
+		}
+		if fault.times > 0 {
+			fault.times--
+			if fault.times == 0 {
+				b.faults = append(b.faults[:i:i], b.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// testBrokerCollections are the object types served by the test broker.
+var testBrokerCollections = []string{"pools", "centers", "gateways"}
+
+// newTestBroker starts a test broker that is stopped when the test ends.
+func newTestBroker(t *testing.T) *testBroker {
+	t.Helper()
+
+	b := &testBroker{
+		username: "terraform",
+		password: "secret",
+		sessions: map[string]bool{},
+		objects:  map[string]map[int64]map[string]any{},
+		nextID:   map[string]int64{},
+	}
+	for _, collection := range testBrokerCollections {
+		b.objects[collection] = map[int64]map[string]any{}
+		b.nextID[collection] = 1
+	}
+
+	b.server = httptest.NewServer(http.HandlerFunc(b.serveHTTP))
+	t.Cleanup(b.server.Close)
+
+	return b
+}
+
+// URL returns the host to configure in the provider.
+func (b *testBroker) URL() string {
+	return b.server.URL
+}
+
+// providerConfig returns the provider block to connect to the test broker.
+func (b *testBroker) providerConfig() string {
+	return fmt.Sprintf(`
+provider "leostream" {
+  host     = %q
+  username = %q
+  password = %q
+}
+`, b.URL(), b.username, b.password)
+}
+
+// get returns a copy of a stored object, as stored, without masking secrets.
+func (b *testBroker) get(collection string, id int64) (map[string]any, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	object, ok := b.objects[collection][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(object), true
+}
+
+// put stores an object as if it was created on the broker, e.g. a center that
+// is referenced by a pool, and returns its ID.
+func (b *testBroker) put(collection string, object map[string]any) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID[collection]
+	b.nextID[collection]++
+	object = copyObject(object)
+	object["id"] = id
+	b.objects[collection][id] = b.withDefaults(collection, object)
+	return id
+}
+
+// checkDestroyed returns a CheckDestroy function that fails when objects of the
+// collection are left on the broker.
+func (b *testBroker) checkDestroyed(collection string) func(*terraform.State) error {
+	return func(_ *terraform.State) error {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if ids := b.sortedIDs(collection); len(ids) > 0 {
+			return fmt.Errorf("%s %v still exist on the broker", collection, ids)
+		}
+		return nil
+	}
+}
+
+func (b *testBroker) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	p, ok := strings.CutPrefix(r.URL.Path, testBrokerAPIPath+"/")
+	if !ok {
+		writeBrokerError(w, http.StatusNotFound, "unknown path "+r.URL.Path)
+		return
+	}
+
+	fault := b.fault(r.Method, p)
+	if fault != nil {
+		time.Sleep(fault.delay)
+
+		switch {
+		case fault.expireSession:
+			b.expireSessions()
+			writeBrokerError(w, http.StatusUnauthorized, "session expired")
+			return
+		case fault.status != 0:
+			writeBrokerError(w, fault.status, "injected fault")
+			return
+		}
+	}
+
+	if p == "session/login" {
+		b.login(w, r)
+		return
+	}
+
+	if !b.authorized(r) {
+		writeBrokerError(w, http.StatusUnauthorized, "session expired or invalid")
+		return
+	}
+
+	collection, rawID, hasID := strings.Cut(p, "/")
+	if _, ok := b.objects[collection]; !ok {
+		writeBrokerError(w, http.StatusNotFound, "unknown path "+r.URL.Path)
+		return
+	}
+
+	if !hasID {
+		switch r.Method {
+		case http.MethodGet:
+			b.list(w, collection)
+		case http.MethodPost:
+			b.create(w, r, collection)
+		default:
+			writeBrokerError(w, http.StatusMethodNotAllowed, r.Method+" is not supported on "+r.URL.Path)
+		}
+		return
+	}
+
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		writeBrokerError(w, http.StatusNotFound, "unknown path "+r.URL.Path)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		b.read(w, collection, id)
+	case http.MethodPut:
+		var ignore []string
+		if fault != nil {
+			ignore = fault.ignore
+		}
+		b.update(w, r, collection, id, ignore)
+	case http.MethodDelete:
+		b.delete(w, collection, id)
+	default:
+		writeBrokerError(w, http.StatusMethodNotAllowed, r.Method+" is not supported on "+r.URL.Path)
+	}
+}
+
+func (b *testBroker) login(w http.ResponseWriter, r *http.Request) {
+	var credentials struct {
+		Username string `json:"user_login"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		writeBrokerError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if credentials.Username != b.username || credentials.Password != b.password {
+		writeBrokerError(w, http.StatusUnauthorized, "invalid username or password")
+		return
+	}
+
+	b.mu.Lock()
+	b.logins++
+	sid := fmt.Sprintf("test-session-%d", b.logins)
+	b.sessions[sid] = true
+	b.mu.Unlock()
+
+	writeBrokerJSON(w, http.StatusOK, map[string]any{"sid": sid})
+}
+
+func (b *testBroker) authorized(r *http.Request) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.sessions[r.Header.Get("Authorization")]
+}
+
+func (b *testBroker) list(w http.ResponseWriter, collection string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	list := []map[string]any{}
+	for _, id := range b.sortedIDs(collection) {
+		list = append(list, listEntry(collection, maskSecrets(collection, b.objects[collection][id])))
+	}
+
+	writeBrokerJSON(w, http.StatusOK, list)
+}
+
+func (b *testBroker) read(w http.ResponseWriter, collection string, id int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	object, ok := b.objects[collection][id]
+	if !ok {
+		writeBrokerError(w, http.StatusNotFound, fmt.Sprintf("%s %d not found", collection, id))
+		return
+	}
+
+	writeBrokerJSON(w, http.StatusOK, maskSecrets(collection, object))
+}
+
+func (b *testBroker) create(w http.ResponseWriter, r *http.Request, collection string) {
+	object, err := decodeObject(r)
+	if err != nil {
+		writeBrokerError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID[collection]
+	b.nextID[collection]++
+	object["id"] = id
+	b.objects[collection][id] = b.withDefaults(collection, object)
+
+	writeBrokerJSON(w, http.StatusOK, map[string]any{"stored_data": map[string]any{"id": id}})
+}
+
+// update merges the sent fields into the stored object, like the broker does
+// for a PUT. A masked or empty secret keeps the stored secret, the ignored fields
+// are not stored.
+func (b *testBroker) update(w http.ResponseWriter, r *http.Request, collection string, id int64, ignore []string) {
+	changes, err := decodeObject(r)
+	if err != nil {
+		writeBrokerError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, field := range ignore {
+		deleteField(changes, field)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	object, ok := b.objects[collection][id]
+	if !ok {
+		writeBrokerError(w, http.StatusNotFound, fmt.Sprintf("%s %d not found", collection, id))
+		return
+	}
+
+	keepSecrets(collection, changes)
+	mergeObject(object, changes)
+	object["id"] = id
+
+	writeBrokerJSON(w, http.StatusOK, map[string]any{"stored_data": map[string]any{"id": id}})
+}
+
+func (b *testBroker) delete(w http.ResponseWriter, collection string, id int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.objects[collection][id]; !ok {
+		writeBrokerError(w, http.StatusNotFound, fmt.Sprintf("%s %d not found", collection, id))
+		return
+	}
+	delete(b.objects[collection], id)
+
+	writeBrokerJSON(w, http.StatusOK, map[string]any{"stored_data": map[string]any{"id": id}})
+}
+
+func (b *testBroker) sortedIDs(collection string) []int64 {
+	var ids []int64
+	for id := range b.objects[collection] {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// withDefaults adds the read-only fields the broker reports for a new object.
+func (b *testBroker) withDefaults(collection string, object map[string]any) map[string]any {
+	if collection != "centers" {
+		return object
+	}
+
+	if _, ok := object["center_info"]; !ok {
+		object["center_info"] = map[string]any{
+			"os":           "",
+			"os_version":   "",
+			"aws_sizes":    []any{"t3.medium", "t3.large"},
+			"aws_sub_nets": []any{"subnet-1"},
+		}
+	}
+	if _, ok := object["images"]; !ok {
+		object["images"] = []any{map[string]any{"id": 1, "name": "ami-1"}}
+	}
+	return object
+}
+
+// listEntry returns the fields of an object included in the list response.
+func listEntry(collection string, object map[string]any) map[string]any {
+	switch collection {
+	case "pools":
+		return map[string]any{"id": object["id"], "name": object["name"]}
+	case "centers":
+		definition, _ := object["center_definition"].(map[string]any)
+		return map[string]any{
+			"id":           object["id"],
+			"name":         definition["name"],
+			"os":           "",
+			"flavor":       "",
+			"online":       1,
+			"status":       1,
+			"status_label": "Online",
+			"center_type":  definition["type"],
+			"type_label":   definition["type"],
+		}
+	default:
+		return object
+	}
+}
+
+// maskSecrets returns a copy of the object with its secrets masked.
+func maskSecrets(collection string, object map[string]any) map[string]any {
+	object = copyObject(object)
+	if collection != "centers" {
+		return object
+	}
+
+	if definition, ok := object["center_definition"].(map[string]any); ok {
+		if password, _ := definition["vc_password"].(string); password != "" {
+			definition["vc_password"] = CONFIG_MASKED_SECRET
+		}
+	}
+	return object
+}
+
+// keepSecrets drops masked or empty secrets from an update, so the stored secret is kept.
+func keepSecrets(collection string, changes map[string]any) {
+	if collection != "centers" {
+		return
+	}
+
+	if definition, ok := changes["center_definition"].(map[string]any); ok {
+		if password, _ := definition["vc_password"].(string); password == "" || password == CONFIG_MASKED_SECRET {
+			delete(definition, "vc_password")
+		}
+	}
+}
+
+// mergeObject merges the fields of src into dst, nested objects are merged field by field.
+func mergeObject(dst map[string]any, src map[string]any) {
+	for key, value := range src {
+		if nested, ok := value.(map[string]any); ok {
+			if existing, ok := dst[key].(map[string]any); ok {
+				mergeObject(existing, nested)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+}
+
+// deleteField deletes a field in dotted notation like "center_definition.notes" from an object.
+func deleteField(object map[string]any, field string) {
+	name, rest, nested := strings.Cut(field, ".")
+	if !nested {
+		delete(object, name)
+		return
+	}
+	if child, ok := object[name].(map[string]any); ok {
+		deleteField(child, rest)
+	}
+}
+
+// copyObject returns a deep copy of a JSON object.
+func copyObject(object map[string]any) map[string]any {
+	raw, err := json.Marshal(object)
+	if err != nil {
+		panic(err)
+	}
+	var c map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&c); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// decodeObject decodes a JSON object from the request body, keeping numbers as sent.
+func decodeObject(r *http.Request) (map[string]any, error) {
+	var object map[string]any
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	if object == nil {
+		return nil, fmt.Errorf("invalid request body: expected a JSON object")
+	}
+	return object, nil
+}
+
+func writeBrokerJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeBrokerError(w http.ResponseWriter, status int, message string) {
+	writeBrokerJSON(w, status, map[string]any{"error": message})
+}
+
+// do sends a request to the test broker and returns the status and decoded response.
+func (b *testBroker) do(t *testing.T, method string, path string, sid string, body any) (int, any) {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, b.URL()+testBrokerAPIPath+path, &reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", sid)
+
+	resp, err := b.server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var decoded any
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		t.Fatalf("%s %s: decoding response: %s", method, path, err)
+	}
+	return resp.StatusCode, decoded
+}
+
+// sid logs in to the test broker and returns the session ID.
+func (b *testBroker) sid(t *testing.T) string {
+	t.Helper()
+
+	_, login := b.do(t, http.MethodPost, "/session/login", "", map[string]any{"user_login": b.username, "password": b.password})
+	sid, _ := login.(map[string]any)["sid"].(string)
+	if sid == "" {
+		t.Fatalf("login returned no sid: %v", login)
+	}
+	return sid
+}
+
+// TestBroker checks the test broker itself, so the acceptance tests can rely on it.
+func TestBroker(t *testing.T) {
+	b := newTestBroker(t)
+
+	do := func(method string, path string, sid string, body any) (int, any) {
+		t.Helper()
+		return b.do(t, method, path, sid, body)
+	}
+
+	if status, _ := do(http.MethodPost, "/session/login", "", map[string]any{"user_login": b.username, "password": "wrong"}); status != http.StatusUnauthorized {
+		t.Errorf("login with a wrong password: got status %d", status)
+	}
+	if status, _ := do(http.MethodGet, "/centers", "invalid", nil); status != http.StatusUnauthorized {
+		t.Errorf("request without a session: got status %d", status)
+	}
+
+	sid := b.sid(t)
+
+	center := map[string]any{"center_definition": map[string]any{"name": "center", "type": "amazon", "vc_password": "aws secret"}}
+	for want := int64(1); want <= 2; want++ {
+		status, stored := do(http.MethodPost, "/centers", sid, center)
+		if status != http.StatusOK {
+			t.Fatalf("create: got status %d", status)
+		}
+		if id := stored.(map[string]any)["stored_data"].(map[string]any)["id"]; id != json.Number(strconv.FormatInt(want, 10)) {
+			t.Errorf("create: got ID %v, want %d", id, want)
+		}
+	}
+
+	_, read := do(http.MethodGet, "/centers/1", sid, nil)
+	if got := read.(map[string]any)["center_definition"].(map[string]any)["vc_password"]; got != CONFIG_MASKED_SECRET {
+		t.Errorf("read: got password %v, want it masked", got)
+	}
+
+	do(http.MethodPut, "/centers/1", sid, map[string]any{"center_definition": map[string]any{"vc_password": CONFIG_MASKED_SECRET, "notes": "updated"}})
+	stored, _ := b.get("centers", 1)
+	definition := stored["center_definition"].(map[string]any)
+	if definition["vc_password"] != "aws secret" || definition["notes"] != "updated" || definition["name"] != "center" {
+		t.Errorf("update: got %v, want the notes updated and the other fields kept", definition)
+	}
+
+	if status, _ := do(http.MethodDelete, "/centers/2", sid, nil); status != http.StatusOK {
+		t.Errorf("delete: got status %d", status)
+	}
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		if status, _ := do(method, "/centers/2", sid, map[string]any{}); status != http.StatusNotFound {
+			t.Errorf("%s of a deleted center: got status %d", method, status)
+		}
+	}
+
+	_, list := do(http.MethodGet, "/centers", sid, nil)
+	if entries := list.([]any); len(entries) != 1 || entries[0].(map[string]any)["name"] != "center" {
+		t.Errorf("list: got %v", list)
+	}
+}
+
+// TestBrokerFaults checks the fault injection of the test broker.
+func TestBrokerFaults(t *testing.T) {
+	b := newTestBroker(t)
+	sid := b.sid(t)
+	id := b.put("gateways", map[string]any{"name": "gateway", "notes": ""})
+	path := "/gateways/" + strconv.FormatInt(id, 10)
+
+	// a fault applies to the matching requests only, as many times as given
+	b.inject(brokerFault{method: http.MethodGet, path: path[1:], times: 2, status: http.StatusInternalServerError})
+	for i, want := range []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK} {
+		if status, _ := b.do(t, http.MethodGet, path, sid, nil); status != want {
+			t.Errorf("request %d: got status %d, want %d", i, status, want)
+		}
+	}
+	if status, _ := b.do(t, http.MethodGet, "/gateways", sid, nil); status != http.StatusOK {
+		t.Errorf("other path: got status %d", status)
+	}
+	if got := b.requestCount(http.MethodGet, path[1:]); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+
+	// slow responses
+	b.inject(brokerFault{path: path[1:], times: 1, delay: 50 * time.Millisecond})
+	start := time.Now()
+	b.do(t, http.MethodGet, path, sid, nil)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("response took %s, want the injected delay", elapsed)
+	}
+
+	// partially applied updates
+	b.inject(brokerFault{method: http.MethodPut, times: 1, ignore: []string{"notes"}})
+	if status, _ := b.do(t, http.MethodPut, path, sid, map[string]any{"name": "renamed", "notes": "lost"}); status != http.StatusOK {
+		t.Errorf("partial update: got status %d", status)
+	}
+	if stored, _ := b.get("gateways", id); stored["name"] != "renamed" || stored["notes"] != "" {
+		t.Errorf("partial update: got %v, want only the name updated", stored)
+	}
+
+	// session expiry
+	b.inject(brokerFault{times: 1, expireSession: true})
+	if status, _ := b.do(t, http.MethodGet, path, sid, nil); status != http.StatusUnauthorized {
+		t.Errorf("expired session: got status %d", status)
+	}
+	if status, _ := b.do(t, http.MethodGet, path, sid, nil); status != http.StatusUnauthorized {
+		t.Errorf("request after the session expired: got status %d", status)
+	}
+	sid = b.sid(t)
+
+	// deletion outside Terraform
+	b.remove("gateways", id)
+	if status, _ := b.do(t, http.MethodGet, path, sid, nil); status != http.StatusNotFound {
+		t.Errorf("removed object: got status %d", status)
+	}
+}
@@ -22,13 +22,13 @@ type centerResourceModel struct {
 // centerDefinitionModel maps filtering schema data
 type centerDefinitionModel struct {
 	Name                  types.String `tfsdk:"name"`
-	Allow_rogue           types.Int64  `tfsdk:"allow_rogue"`
+	Allow_rogue           types.Bool   `tfsdk:"allow_rogue"`
 	Allow_rogue_policy_id types.Int64  `tfsdk:"allow_rogue_policy_id"`
-	Continuous_autotag    types.Int64  `tfsdk:"continuous_autotag"`
-	Init_unavailable      types.Int64  `tfsdk:"init_unavailable"`
-	New_as_deletable      types.Int64  `tfsdk:"new_as_deletable"`
+	Continuous_autotag    types.Bool   `tfsdk:"continuous_autotag"`
+	Init_unavailable      types.Bool   `tfsdk:"init_unavailable"`
+	New_as_deletable      types.Bool   `tfsdk:"new_as_deletable"`
 	Notes                 types.String `tfsdk:"notes"`
-	Offer_vms             types.Int64  `tfsdk:"offer_vms"`
+	Offer_vms             types.Bool   `tfsdk:"offer_vms"`
 	Poll_interval         types.Int64  `tfsdk:"poll_interval"`
 	Proxy_address         types.String `tfsdk:"proxy_address"`
 	Type                  types.String `tfsdk:"type"`
@@ -36,21 +36,22 @@ type centerDefinitionModel struct {
 	Vc_datacenter         types.String `tfsdk:"vc_datacenter"`
 	Vc_name               types.String `tfsdk:"vc_name"`
 	Vc_password           types.String `tfsdk:"vc_password"`
-	Wait_inst_status      types.Int64  `tfsdk:"wait_inst_status"`
-	Wait_sys_status       types.Int64  `tfsdk:"wait_sys_status"`
+	Vc_password_version   types.Int64  `tfsdk:"vc_password_version"`
+	Wait_inst_status      types.Bool   `tfsdk:"wait_inst_status"`
+	Wait_sys_status       types.Bool   `tfsdk:"wait_sys_status"`
 }
 
 // attrTypes - return attribute types for this model
 func (o centerDefinitionModel) attrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"name":                  types.StringType,
-		"allow_rogue":           types.Int64Type,
+		"allow_rogue":           types.BoolType,
 		"allow_rogue_policy_id": types.Int64Type,
-		"continuous_autotag":    types.Int64Type,
-		"init_unavailable":      types.Int64Type,
-		"new_as_deletable":      types.Int64Type,
+		"continuous_autotag":    types.BoolType,
+		"init_unavailable":      types.BoolType,
+		"new_as_deletable":      types.BoolType,
 		"notes":                 types.StringType,
-		"offer_vms":             types.Int64Type,
+		"offer_vms":             types.BoolType,
 		"poll_interval":         types.Int64Type,
 		"proxy_address":         types.StringType,
 		"type":                  types.StringType,
@@ -58,8 +59,9 @@ func (o centerDefinitionModel) attrTypes() map[string]attr.Type {
 		"vc_datacenter":         types.StringType,
 		"vc_name":               types.StringType,
 		"vc_password":           types.StringType,
-		"wait_inst_status":      types.Int64Type,
-		"wait_sys_status":       types.Int64Type,
+		"vc_password_version":   types.Int64Type,
+		"wait_inst_status":      types.BoolType,
+		"wait_sys_status":       types.BoolType,
 	}
 }
 
@@ -67,51 +69,66 @@ func (o centerDefinitionModel) attrTypes() map[string]attr.Type {
 func (o centerDefinitionModel) defaultObject() map[string]attr.Value {
 	return map[string]attr.Value{
 		"name":                  types.StringValue(""),
-		"allow_rogue":           types.Int64Value(0),
+		"allow_rogue":           types.BoolValue(false),
 		"allow_rogue_policy_id": types.Int64Value(0),
-		"continuous_autotag":    types.Int64Value(0),
-		"init_unavailable":      types.Int64Value(0),
-		"new_as_deletable":      types.Int64Value(0),
+		"continuous_autotag":    types.BoolValue(false),
+		"init_unavailable":      types.BoolValue(false),
+		"new_as_deletable":      types.BoolValue(false),
 		"notes":                 types.StringValue(""),
-		"offer_vms":             types.Int64Value(1),
+		"offer_vms":             types.BoolValue(true),
 		"poll_interval":         types.Int64Value(1),
 		"proxy_address":         types.StringValue(""),
 		"type":                  types.StringValue("amazon"),
 		"vc_auth_method":        types.StringValue(""),
 		"vc_datacenter":         types.StringValue(""),
 		"vc_name":               types.StringValue(""),
-		"vc_password":           types.StringValue("**********"),
-		"wait_inst_status":      types.Int64Value(0),
-		"wait_sys_status":       types.Int64Value(0),
+		"vc_password":           types.StringNull(),
+		"vc_password_version":   types.Int64Null(),
+		"wait_inst_status":      types.BoolValue(false),
+		"wait_sys_status":       types.BoolValue(false),
 	}
 }
 
-// common `Read` function for both data source and resource
-func (o *centerResourceModel) Read(ctx context.Context, client leostream.Client, diags *diag.Diagnostics, rtype string, id string) {
+// common `Read` function for both data source and resource, returns false when the object no longer exists
+func (o *centerResourceModel) Read(ctx context.Context, client apiClient, diags *diag.Diagnostics, rtype string, id string) bool {
 	//center CONFIG
 	//get refreshed center config value from Leostream API
 	centerConfig, err := client.GetCenter(id)
 
+	if isNotFound(err) {
+		return false
+	}
+
 	if err != nil {
 		diags.AddError(
 			"Unable to read center Configuration",
 			err.Error(),
 		)
-		return
+		return false
 	}
 
+	*o = centerStateFromConfig(ctx, centerConfig, diags)
+
+	return !diags.HasError()
+}
+
+// centerStateFromConfig converts a center returned by the Leostream API into the resource model.
+// A stored secret is returned masked, see preserveSecrets.
+func centerStateFromConfig(ctx context.Context, centerConfig *leostream.Center, diags *diag.Diagnostics) centerResourceModel {
+	var o centerResourceModel
+
 	o.ID = types.StringValue(strconv.FormatInt(centerConfig.ID, 10))
 
 	// Map center definition to state
 	var statecenterDefinition centerDefinitionModel
 	statecenterDefinition.Name = types.StringValue(centerConfig.Center_definition.Name)
-	statecenterDefinition.Allow_rogue = types.Int64Value(centerConfig.Center_definition.Allow_rogue)
+	statecenterDefinition.Allow_rogue = types.BoolValue(centerConfig.Center_definition.Allow_rogue != 0)
 	statecenterDefinition.Allow_rogue_policy_id = types.Int64Value(centerConfig.Center_definition.Allow_rogue_policy_id)
-	statecenterDefinition.Continuous_autotag = types.Int64Value(centerConfig.Center_definition.Continuous_autotag)
-	statecenterDefinition.Init_unavailable = types.Int64Value(centerConfig.Center_definition.Init_unavailable)
-	statecenterDefinition.New_as_deletable = types.Int64Value(centerConfig.Center_definition.New_as_deletable)
+	statecenterDefinition.Continuous_autotag = types.BoolValue(centerConfig.Center_definition.Continuous_autotag != 0)
+	statecenterDefinition.Init_unavailable = types.BoolValue(centerConfig.Center_definition.Init_unavailable != 0)
+	statecenterDefinition.New_as_deletable = types.BoolValue(centerConfig.Center_definition.New_as_deletable != 0)
 	statecenterDefinition.Notes = types.StringValue(centerConfig.Center_definition.Notes)
-	statecenterDefinition.Offer_vms = types.Int64Value(centerConfig.Center_definition.Offer_vms)
+	statecenterDefinition.Offer_vms = types.BoolValue(centerConfig.Center_definition.Offer_vms != 0)
 	statecenterDefinition.Poll_interval = types.Int64Value(centerConfig.Center_definition.Poll_interval)
 	statecenterDefinition.Proxy_address = types.StringValue(centerConfig.Center_definition.Proxy_address)
 	statecenterDefinition.Type = types.StringValue(centerConfig.Center_definition.Type)
@@ -119,55 +136,51 @@ func (o *centerResourceModel) Read(ctx context.Context, client leostream.Client,
 	statecenterDefinition.Vc_datacenter = types.StringValue(centerConfig.Center_definition.Vc_datacenter)
 	statecenterDefinition.Vc_name = types.StringValue(centerConfig.Center_definition.Vc_name)
 	statecenterDefinition.Vc_password = types.StringValue(centerConfig.Center_definition.Vc_password)
-	statecenterDefinition.Wait_inst_status = types.Int64Value(centerConfig.Center_definition.Wait_inst_status)
-	statecenterDefinition.Wait_sys_status = types.Int64Value(centerConfig.Center_definition.Wait_sys_status)
+	statecenterDefinition.Wait_inst_status = types.BoolValue(centerConfig.Center_definition.Wait_inst_status != 0)
+	statecenterDefinition.Wait_sys_status = types.BoolValue(centerConfig.Center_definition.Wait_sys_status != 0)
 
 	//Add center definition to center model
-	o.Center_definition, _ = types.ObjectValueFrom(ctx, centerDefinitionModel{}.attrTypes(), &statecenterDefinition)
+	var d diag.Diagnostics
+	o.Center_definition, d = types.ObjectValueFrom(ctx, centerDefinitionModel{}.attrTypes(), &statecenterDefinition)
+	diags.Append(d...)
 
+	return o
 }
 
-// `Create` function for the resource
-func (r *centerResource) CreateNested(ctx context.Context, plan *centerResourceModel, state *centerResourceModel, diags *diag.Diagnostics) *leostream.CenterStored {
-	// center CONFIG
+// preserveSecrets keeps the secrets of the prior state, as the API only returns them masked
+func (o *centerResourceModel) preserveSecrets(ctx context.Context, prior *centerResourceModel, diags *diag.Diagnostics) {
+	if o.Center_definition.IsNull() || o.Center_definition.IsUnknown() {
+		return
+	}
 
-	// Instantiate empty object for storing plan data
-	var centerConfig leostream.Center
+	var centerDefinition centerDefinitionModel
+	diags.Append(o.Center_definition.As(ctx, &centerDefinition, basetypes.ObjectAsOptions{})...)
 
-	// Unpack nested attributes from plan for the center definition
-	var plancenterDefinition centerDefinitionModel
-	*diags = plan.Center_definition.As(ctx, &plancenterDefinition, basetypes.ObjectAsOptions{})
+	var priorCenterDefinition centerDefinitionModel
+	if !prior.Center_definition.IsNull() && !prior.Center_definition.IsUnknown() {
+		diags.Append(prior.Center_definition.As(ctx, &priorCenterDefinition, basetypes.ObjectAsOptions{})...)
+	}
 	if diags.HasError() {
-		return nil
+		return
 	}
 
-	// Instantiate empty object for storing plan data for the center definition object in the center config
-	var centerDefinitionConfig leostream.CenterDefinition
+	centerDefinition.Vc_password = secretFromApi(centerDefinition.Vc_password.ValueString(), priorCenterDefinition.Vc_password)
+	centerDefinition.Vc_password_version = priorCenterDefinition.Vc_password_version
 
-	// Populate center_definition field restrict_by in empty object from plan
-	centerDefinitionConfig.Name = plancenterDefinition.Name.ValueString()
-	centerDefinitionConfig.Allow_rogue = plancenterDefinition.Allow_rogue.ValueInt64()
-	centerDefinitionConfig.Allow_rogue_policy_id = plancenterDefinition.Allow_rogue_policy_id.ValueInt64()
-	centerDefinitionConfig.Continuous_autotag = plancenterDefinition.Continuous_autotag.ValueInt64()
-	centerDefinitionConfig.Init_unavailable = plancenterDefinition.Init_unavailable.ValueInt64()
-	centerDefinitionConfig.New_as_deletable = plancenterDefinition.New_as_deletable.ValueInt64()
-	centerDefinitionConfig.Notes = plancenterDefinition.Notes.ValueString()
-	centerDefinitionConfig.Offer_vms = plancenterDefinition.Offer_vms.ValueInt64()
-	centerDefinitionConfig.Poll_interval = plancenterDefinition.Poll_interval.ValueInt64()
-	centerDefinitionConfig.Proxy_address = plancenterDefinition.Proxy_address.ValueString()
-	centerDefinitionConfig.Type = plancenterDefinition.Type.ValueString()
-	centerDefinitionConfig.Vc_auth_method = plancenterDefinition.Vc_auth_method.ValueString()
-	centerDefinitionConfig.Vc_datacenter = plancenterDefinition.Vc_datacenter.ValueString()
-	centerDefinitionConfig.Vc_name = plancenterDefinition.Vc_name.ValueString()
-	centerDefinitionConfig.Vc_password = plancenterDefinition.Vc_password.ValueString()
-	centerDefinitionConfig.Wait_inst_status = plancenterDefinition.Wait_inst_status.ValueInt64()
-	centerDefinitionConfig.Wait_sys_status = plancenterDefinition.Wait_sys_status.ValueInt64()
+	var d diag.Diagnostics
+	o.Center_definition, d = types.ObjectValueFrom(ctx, centerDefinitionModel{}.attrTypes(), &centerDefinition)
+	diags.Append(d...)
+}
 
-	// Assign the center definition config to the center config
-	centerConfig.Center_definition = centerDefinitionConfig
+// `Create` function for the resource
+func (r *centerResource) CreateNested(ctx context.Context, plan *centerResourceModel, state *centerResourceModel, diags *diag.Diagnostics) *leostream.CenterStored {
+	centerConfig := centerConfigFromPlan(ctx, plan, nil, diags)
+	if centerConfig == nil {
+		return nil
+	}
 
 	// Create new center
-	centersStored, err := r.client.CreateCenter(centerConfig, nil)
+	centersStored, err := r.client.CreateCenter(*centerConfig, nil)
 
 	if err != nil {
 		diags.AddError(
@@ -182,6 +195,28 @@ func (r *centerResource) CreateNested(ctx context.Context, plan *centerResourceM
 
 // `Update` function for the resource
 func (r *centerResource) UpdateNested(ctx context.Context, plan *centerResourceModel, state *centerResourceModel, diags *diag.Diagnostics) *leostream.CenterStored {
+	centerConfig := centerConfigFromPlan(ctx, plan, state, diags)
+	if centerConfig == nil {
+		return nil
+	}
+
+	// Update center
+	centersStored, err := r.client.UpdateCenter(plan.ID.ValueString(), *centerConfig, nil)
+
+	if err != nil {
+		diags.AddError(
+			"Unable to modify center",
+			err.Error(),
+		)
+		return nil
+	} else {
+		return centersStored
+	}
+}
+
+// centerConfigFromPlan converts the plan into the center config sent to the Leostream API.
+// The state is nil for a new center. It returns nil when the plan could not be converted.
+func centerConfigFromPlan(ctx context.Context, plan *centerResourceModel, state *centerResourceModel, diags *diag.Diagnostics) *leostream.Center {
 	// center CONFIG
 
 	// Instantiate empty object for storing plan data
@@ -189,44 +224,47 @@ func (r *centerResource) UpdateNested(ctx context.Context, plan *centerResourceM
 
 	// Unpack nested attributes from plan for the center definition
 	var plancenterDefinition centerDefinitionModel
-	*diags = plan.Center_definition.As(ctx, &plancenterDefinition, basetypes.ObjectAsOptions{})
+	diags.Append(plan.Center_definition.As(ctx, &plancenterDefinition, basetypes.ObjectAsOptions{})...)
 	if diags.HasError() {
 		return nil
 	}
 	// Instantiate empty object for storing plan data for the center definition object in the center config
 	var centerDefinitionConfig leostream.CenterDefinition
 
+	// Unpack nested attributes from state, to detect a changed secret
+	var statecenterDefinition centerDefinitionModel
+	if state != nil && !state.Center_definition.IsNull() {
+		diags.Append(state.Center_definition.As(ctx, &statecenterDefinition, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return nil
+		}
+	}
+
 	// Populate center definition config from plan
 	centerDefinitionConfig.Name = plancenterDefinition.Name.ValueString()
-	centerDefinitionConfig.Allow_rogue = plancenterDefinition.Allow_rogue.ValueInt64()
+	centerDefinitionConfig.Allow_rogue = boolToInt64(plancenterDefinition.Allow_rogue.ValueBool())
 	centerDefinitionConfig.Allow_rogue_policy_id = plancenterDefinition.Allow_rogue_policy_id.ValueInt64()
-	centerDefinitionConfig.Continuous_autotag = plancenterDefinition.Continuous_autotag.ValueInt64()
-	centerDefinitionConfig.Init_unavailable = plancenterDefinition.Init_unavailable.ValueInt64()
-	centerDefinitionConfig.New_as_deletable = plancenterDefinition.New_as_deletable.ValueInt64()
+	centerDefinitionConfig.Continuous_autotag = boolToInt64(plancenterDefinition.Continuous_autotag.ValueBool())
+	centerDefinitionConfig.Init_unavailable = boolToInt64(plancenterDefinition.Init_unavailable.ValueBool())
+	centerDefinitionConfig.New_as_deletable = boolToInt64(plancenterDefinition.New_as_deletable.ValueBool())
 	centerDefinitionConfig.Notes = plancenterDefinition.Notes.ValueString()
-	centerDefinitionConfig.Offer_vms = plancenterDefinition.Offer_vms.ValueInt64()
+	centerDefinitionConfig.Offer_vms = boolToInt64(plancenterDefinition.Offer_vms.ValueBool())
 	centerDefinitionConfig.Poll_interval = plancenterDefinition.Poll_interval.ValueInt64()
 	centerDefinitionConfig.Proxy_address = plancenterDefinition.Proxy_address.ValueString()
 	centerDefinitionConfig.Type = plancenterDefinition.Type.ValueString()
 	centerDefinitionConfig.Vc_auth_method = plancenterDefinition.Vc_auth_method.ValueString()
 	centerDefinitionConfig.Vc_datacenter = plancenterDefinition.Vc_datacenter.ValueString()
 	centerDefinitionConfig.Vc_name = plancenterDefinition.Vc_name.ValueString()
-	centerDefinitionConfig.Wait_inst_status = plancenterDefinition.Wait_inst_status.ValueInt64()
-	centerDefinitionConfig.Wait_sys_status = plancenterDefinition.Wait_sys_status.ValueInt64()
+	centerDefinitionConfig.Wait_inst_status = boolToInt64(plancenterDefinition.Wait_inst_status.ValueBool())
+	centerDefinitionConfig.Wait_sys_status = boolToInt64(plancenterDefinition.Wait_sys_status.ValueBool())
+
+	// Only send the secret when it is new, changed or rotated, the API keeps the stored value otherwise
+	if secretChanged(plancenterDefinition.Vc_password, statecenterDefinition.Vc_password, plancenterDefinition.Vc_password_version, statecenterDefinition.Vc_password_version) {
+		centerDefinitionConfig.Vc_password = plancenterDefinition.Vc_password.ValueString()
+	}
 
 	// Assign the center definition config to the center config
 	centerConfig.Center_definition = centerDefinitionConfig
 
-	// Update center
-	centersStored, err := r.client.UpdateCenter(plan.ID.ValueString(), centerConfig, nil)
-
-	if err != nil {
-		diags.AddError(
-			"Unable to modify center",
-			err.Error(),
-		)
-		return nil
-	} else {
-		return centersStored
-	}
+	return &centerConfig
 }
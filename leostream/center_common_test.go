@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gitlab.hocmodo.nl/community/leostream-client-go"
+)
+
+// testCenter returns a center with the default center definition, with the
+// given attributes replaced.
+func testCenter(id string, definition map[string]attr.Value) *centerResourceModel {
+	return &centerResourceModel{
+		ID: types.StringValue(id),
+		Center_definition: types.ObjectValueMust(
+			centerDefinitionModel{}.attrTypes(),
+			mergeAttributes(centerDefinitionModel{}.defaultObject(), map[string]attr.Value{"name": types.StringValue("center")}, definition),
+		),
+	}
+}
+
+func TestCenterConfigFromPlan(t *testing.T) {
+	ctx := context.Background()
+
+	secret := map[string]attr.Value{"vc_password": types.StringValue("secret")}
+
+	tests := map[string]struct {
+		plan  map[string]attr.Value
+		state map[string]attr.Value
+		// create converts the plan of a new center, without state
+		create bool
+		want   func(*leostream.CenterDefinition)
+	}{
+		"defaults": {
+			create: true,
+		},
+		"new password": {
+			plan:   secret,
+			create: true,
+			want:   func(d *leostream.CenterDefinition) { d.Vc_password = "secret" },
+		},
+		"unchanged password": {
+			plan:  secret,
+			state: secret,
+		},
+		"changed password": {
+			plan:  map[string]attr.Value{"vc_password": types.StringValue("changed")},
+			state: secret,
+			want:  func(d *leostream.CenterDefinition) { d.Vc_password = "changed" },
+		},
+		"rotated password": {
+			plan:  map[string]attr.Value{"vc_password": types.StringValue("secret"), "vc_password_version": types.Int64Value(2)},
+			state: map[string]attr.Value{"vc_password": types.StringValue("secret"), "vc_password_version": types.Int64Value(1)},
+			want:  func(d *leostream.CenterDefinition) { d.Vc_password = "secret" },
+		},
+		"removed password": {
+			state: secret,
+		},
+		"unknown password": {
+			plan:   map[string]attr.Value{"vc_password": types.StringUnknown()},
+			create: true,
+		},
+		"flags": {
+			plan: map[string]attr.Value{
+				"allow_rogue":      types.BoolValue(true),
+				"offer_vms":        types.BoolValue(false),
+				"wait_inst_status": types.BoolValue(true),
+			},
+			create: true,
+			want: func(d *leostream.CenterDefinition) {
+				d.Allow_rogue = 1
+				d.Offer_vms = 0
+				d.Wait_inst_status = 1
+			},
+		},
+		"null and unknown values": {
+			plan:   map[string]attr.Value{"notes": types.StringNull(), "poll_interval": types.Int64Unknown()},
+			create: true,
+			want:   func(d *leostream.CenterDefinition) { d.Poll_interval = 0 },
+		},
+		"aws fields": {
+			plan: map[string]attr.Value{
+				"vc_datacenter":  types.StringValue("us-east-1"),
+				"vc_name":        types.StringValue("access key"),
+				"vc_auth_method": types.StringValue("access_key"),
+			},
+			create: true,
+			want: func(d *leostream.CenterDefinition) {
+				d.Vc_datacenter = "us-east-1"
+				d.Vc_name = "access key"
+				d.Vc_auth_method = "access_key"
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plan := testCenter("1", test.plan)
+			var state *centerResourceModel
+			if !test.create {
+				state = testCenter("1", test.state)
+			}
+
+			want := leostream.Center{Center_definition: leostream.CenterDefinition{
+				Name:          "center",
+				Offer_vms:     1,
+				Poll_interval: 1,
+				Type:          CONFIG_CENTER_TYPE_AMAZON,
+			}}
+			if test.want != nil {
+				test.want(&want.Center_definition)
+			}
+
+			var diags diag.Diagnostics
+			got := centerConfigFromPlan(ctx, plan, state, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !reflect.DeepEqual(got, &want) {
+				t.Errorf("got\n%+v\nwant\n%+v", *got, want)
+			}
+		})
+	}
+}
+
+func TestCenterConfigFromPlanInvalid(t *testing.T) {
+	plan := testCenter("1", nil)
+	plan.Center_definition = types.ObjectUnknown(centerDefinitionModel{}.attrTypes())
+
+	var diags diag.Diagnostics
+	if got := centerConfigFromPlan(context.Background(), plan, nil, &diags); got != nil || !diags.HasError() {
+		t.Errorf("got %+v and %v, want an error", got, diags)
+	}
+}
+
+func TestCenterStateFromConfig(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		config func(*leostream.CenterDefinition)
+		want   map[string]attr.Value
+	}{
+		"defaults": {},
+		"flags": {
+			config: func(d *leostream.CenterDefinition) {
+				d.Continuous_autotag = 1
+				d.Offer_vms = 0
+				d.Wait_sys_status = 1
+			},
+			want: map[string]attr.Value{
+				"continuous_autotag": types.BoolValue(true),
+				"offer_vms":          types.BoolValue(false),
+				"wait_sys_status":    types.BoolValue(true),
+			},
+		},
+		// the masked password is replaced by preserveSecrets
+		"masked password": {
+			config: func(d *leostream.CenterDefinition) { d.Vc_password = CONFIG_MASKED_SECRET },
+			want:   map[string]attr.Value{"vc_password": types.StringValue(CONFIG_MASKED_SECRET)},
+		},
+		"aws fields": {
+			config: func(d *leostream.CenterDefinition) {
+				d.Vc_datacenter = "us-east-1"
+				d.Proxy_address = "proxy.example.com"
+			},
+			want: map[string]attr.Value{
+				"vc_datacenter": types.StringValue("us-east-1"),
+				"proxy_address": types.StringValue("proxy.example.com"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := leostream.Center{ID: 3, Center_definition: leostream.CenterDefinition{
+				Name:          "center",
+				Offer_vms:     1,
+				Poll_interval: 1,
+				Type:          CONFIG_CENTER_TYPE_AMAZON,
+			}}
+			if test.config != nil {
+				test.config(&config.Center_definition)
+			}
+
+			// the API returns an empty password when none is stored
+			want := testCenter("3", mergeAttributes(map[string]attr.Value{"vc_password": types.StringValue("")}, test.want))
+
+			var diags diag.Diagnostics
+			got := centerStateFromConfig(ctx, &config, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !reflect.DeepEqual(got, *want) {
+				t.Errorf("got\n%+v\nwant\n%+v", got, *want)
+			}
+		})
+	}
+}
@@ -66,7 +66,7 @@ func (d *centerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Optional:    true,
 						Computed:    true,
 					},
-					"allow_rogue": schema.Int64Attribute{
+					"allow_rogue": schema.BoolAttribute{
 						Description: "Assign rogue users to desktops from this center.",
 						Optional:    true,
 						Computed:    true,
@@ -76,17 +76,17 @@ func (d *centerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Optional:    true,
 						Computed:    true,
 					},
-					"continuous_autotag": schema.Int64Attribute{
+					"continuous_autotag": schema.BoolAttribute{
 						Description: "Apply auto-tags every time center is scanned.",
 						Optional:    true,
 						Computed:    true,
 					},
-					"init_unavailable": schema.Int64Attribute{
+					"init_unavailable": schema.BoolAttribute{
 						Description: "Initialize desktops as unavailable.",
 						Optional:    true,
 						Computed:    true,
 					},
-					"new_as_deletable": schema.Int64Attribute{
+					"new_as_deletable": schema.BoolAttribute{
 						Description: "New desktops are deletable.",
 						Optional:    true,
 						Computed:    true,
@@ -96,7 +96,7 @@ func (d *centerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Optional:    true,
 						Computed:    true,
 					},
-					"offer_vms": schema.Int64Attribute{
+					"offer_vms": schema.BoolAttribute{
 						Description: "Offer VMs to users from this center.",
 						Optional:    true,
 						Computed:    true,
@@ -132,16 +132,17 @@ func (d *centerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Computed:    true,
 					},
 					"vc_password": schema.StringAttribute{
-						Description: "The Secret Access Key for the user.",
+						Description: "The Secret Access Key for the user. Always masked by the Leostream API.",
 						Optional:    true,
 						Computed:    true,
+						Sensitive:   true,
 					},
-					"wait_inst_status": schema.Int64Attribute{
+					"wait_inst_status": schema.BoolAttribute{
 						Description: "Wait for instance status to be running before assigning desktops.",
 						Optional:    true,
 						Computed:    true,
 					},
-					"wait_sys_status": schema.Int64Attribute{
+					"wait_sys_status": schema.BoolAttribute{
 						Description: "Wait for system status to be valid before assigning desktops.",
 						Optional:    true,
 						Computed:    true,
@@ -186,6 +187,9 @@ func (d *centerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	diags := req.Config.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	center, err := d.client.GetCenter(state.ID.String())
 	if err != nil {
@@ -203,13 +207,13 @@ func (d *centerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	var stateCenterDefinitionDataSourceModel centerDefinitionDataSourceModel
 
 	stateCenterDefinitionDataSourceModel.Name = types.StringValue(center.Center_definition.Name)
-	stateCenterDefinitionDataSourceModel.Allow_rogue = types.Int64Value(center.Center_definition.Allow_rogue)
+	stateCenterDefinitionDataSourceModel.Allow_rogue = types.BoolValue(center.Center_definition.Allow_rogue != 0)
 	stateCenterDefinitionDataSourceModel.Allow_rogue_policy_id = types.Int64Value(center.Center_definition.Allow_rogue_policy_id)
-	stateCenterDefinitionDataSourceModel.Continuous_autotag = types.Int64Value(center.Center_definition.Continuous_autotag)
-	stateCenterDefinitionDataSourceModel.Init_unavailable = types.Int64Value(center.Center_definition.Init_unavailable)
-	stateCenterDefinitionDataSourceModel.New_as_deletable = types.Int64Value(center.Center_definition.New_as_deletable)
+	stateCenterDefinitionDataSourceModel.Continuous_autotag = types.BoolValue(center.Center_definition.Continuous_autotag != 0)
+	stateCenterDefinitionDataSourceModel.Init_unavailable = types.BoolValue(center.Center_definition.Init_unavailable != 0)
+	stateCenterDefinitionDataSourceModel.New_as_deletable = types.BoolValue(center.Center_definition.New_as_deletable != 0)
 	stateCenterDefinitionDataSourceModel.Notes = types.StringValue(center.Center_definition.Notes)
-	stateCenterDefinitionDataSourceModel.Offer_vms = types.Int64Value(center.Center_definition.Offer_vms)
+	stateCenterDefinitionDataSourceModel.Offer_vms = types.BoolValue(center.Center_definition.Offer_vms != 0)
 	stateCenterDefinitionDataSourceModel.Poll_interval = types.Int64Value(center.Center_definition.Poll_interval)
 	stateCenterDefinitionDataSourceModel.Proxy_address = types.StringValue(center.Center_definition.Proxy_address)
 	stateCenterDefinitionDataSourceModel.Type = types.StringValue(center.Center_definition.Type)
@@ -217,21 +221,25 @@ func (d *centerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	stateCenterDefinitionDataSourceModel.Vc_datacenter = types.StringValue(center.Center_definition.Vc_datacenter)
 	stateCenterDefinitionDataSourceModel.Vc_name = types.StringValue(center.Center_definition.Vc_name)
 	stateCenterDefinitionDataSourceModel.Vc_password = types.StringValue(center.Center_definition.Vc_password)
-	stateCenterDefinitionDataSourceModel.Wait_inst_status = types.Int64Value(center.Center_definition.Wait_inst_status)
-	stateCenterDefinitionDataSourceModel.Wait_sys_status = types.Int64Value(center.Center_definition.Wait_sys_status)
+	stateCenterDefinitionDataSourceModel.Wait_inst_status = types.BoolValue(center.Center_definition.Wait_inst_status != 0)
+	stateCenterDefinitionDataSourceModel.Wait_sys_status = types.BoolValue(center.Center_definition.Wait_sys_status != 0)
 
 	// Map response body to model
-	state.Center_definition, _ = types.ObjectValueFrom(ctx, centerDefinitionDataSourceModel{}.attrTypes(), &stateCenterDefinitionDataSourceModel)
+	state.Center_definition, diags = types.ObjectValueFrom(ctx, centerDefinitionDataSourceModel{}.attrTypes(), &stateCenterDefinitionDataSourceModel)
+	resp.Diagnostics.Append(diags...)
 
 	// Create a new center_info state model
 	var stateCenterInfoDataSourceModel centerInfoDataSourceModel
-	stateCenterInfoDataSourceModel.Aws_sizes, _ = types.ListValueFrom(ctx, types.StringType, center.Center_info.Aws_sizes)
-	stateCenterInfoDataSourceModel.Aws_sub_nets, _ = types.ListValueFrom(ctx, types.StringType, center.Center_info.Aws_sub_nets)
+	stateCenterInfoDataSourceModel.Aws_sizes, diags = types.ListValueFrom(ctx, types.StringType, center.Center_info.Aws_sizes)
+	resp.Diagnostics.Append(diags...)
+	stateCenterInfoDataSourceModel.Aws_sub_nets, diags = types.ListValueFrom(ctx, types.StringType, center.Center_info.Aws_sub_nets)
+	resp.Diagnostics.Append(diags...)
 	stateCenterInfoDataSourceModel.Os = types.StringValue(center.Center_info.Os)
 	stateCenterInfoDataSourceModel.Os_version = types.StringValue(center.Center_info.Os_version)
 
 	// Map response body to model
-	state.Center_info, _ = types.ObjectValueFrom(ctx, centerInfoDataSourceModel{}.attrTypes(), &stateCenterInfoDataSourceModel)
+	state.Center_info, diags = types.ObjectValueFrom(ctx, centerInfoDataSourceModel{}.attrTypes(), &stateCenterInfoDataSourceModel)
+	resp.Diagnostics.Append(diags...)
 
 	var stateImages []imagesModel
 	// Loop through images and convert to state model
@@ -243,7 +251,11 @@ func (d *centerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		stateImages = append(stateImages, stateImage)
 	}
 
-	state.Images, _ = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: imagesModel{}.attrTypes()}, stateImages)
+	state.Images, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: imagesModel{}.attrTypes()}, stateImages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set state
 	diags = resp.State.Set(ctx, &state)
@@ -265,13 +277,13 @@ func (d *centerDataSource) Configure(_ context.Context, req datasource.Configure
 // centersDataSourceModel maps the data source schema data.
 type centerDefinitionDataSourceModel struct {
 	Name                  types.String `tfsdk:"name"`
-	Allow_rogue           types.Int64  `tfsdk:"allow_rogue"`
+	Allow_rogue           types.Bool   `tfsdk:"allow_rogue"`
 	Allow_rogue_policy_id types.Int64  `tfsdk:"allow_rogue_policy_id"`
-	Continuous_autotag    types.Int64  `tfsdk:"continuous_autotag"`
-	Init_unavailable      types.Int64  `tfsdk:"init_unavailable"`
-	New_as_deletable      types.Int64  `tfsdk:"new_as_deletable"`
+	Continuous_autotag    types.Bool   `tfsdk:"continuous_autotag"`
+	Init_unavailable      types.Bool   `tfsdk:"init_unavailable"`
+	New_as_deletable      types.Bool   `tfsdk:"new_as_deletable"`
 	Notes                 types.String `tfsdk:"notes"`
-	Offer_vms             types.Int64  `tfsdk:"offer_vms"`
+	Offer_vms             types.Bool   `tfsdk:"offer_vms"`
 	Poll_interval         types.Int64  `tfsdk:"poll_interval"`
 	Proxy_address         types.String `tfsdk:"proxy_address"`
 	Type                  types.String `tfsdk:"type"`
@@ -279,21 +291,21 @@ type centerDefinitionDataSourceModel struct {
 	Vc_datacenter         types.String `tfsdk:"vc_datacenter"`
 	Vc_name               types.String `tfsdk:"vc_name"`
 	Vc_password           types.String `tfsdk:"vc_password"`
-	Wait_inst_status      types.Int64  `tfsdk:"wait_inst_status"`
-	Wait_sys_status       types.Int64  `tfsdk:"wait_sys_status"`
+	Wait_inst_status      types.Bool   `tfsdk:"wait_inst_status"`
+	Wait_sys_status       types.Bool   `tfsdk:"wait_sys_status"`
 }
 
 // attrTypes - return attribute types for this model
 func (o centerDefinitionDataSourceModel) attrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"name":                  types.StringType,
-		"allow_rogue":           types.Int64Type,
+		"allow_rogue":           types.BoolType,
 		"allow_rogue_policy_id": types.Int64Type,
-		"continuous_autotag":    types.Int64Type,
-		"init_unavailable":      types.Int64Type,
-		"new_as_deletable":      types.Int64Type,
+		"continuous_autotag":    types.BoolType,
+		"init_unavailable":      types.BoolType,
+		"new_as_deletable":      types.BoolType,
 		"notes":                 types.StringType,
-		"offer_vms":             types.Int64Type,
+		"offer_vms":             types.BoolType,
 		"poll_interval":         types.Int64Type,
 		"proxy_address":         types.StringType,
 		"type":                  types.StringType,
@@ -301,8 +313,8 @@ func (o centerDefinitionDataSourceModel) attrTypes() map[string]attr.Type {
 		"vc_datacenter":         types.StringType,
 		"vc_name":               types.StringType,
 		"vc_password":           types.StringType,
-		"wait_inst_status":      types.Int64Type,
-		"wait_sys_status":       types.Int64Type,
+		"wait_inst_status":      types.BoolType,
+		"wait_sys_status":       types.BoolType,
 	}
 }
 
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCenterDataSource(t *testing.T) {
+	broker := newTestBroker(t)
+	broker.put("centers", map[string]any{
+		"center_definition": map[string]any{
+			"name":          "aws-center-us-east-1",
+			"type":          "amazon",
+			"vc_datacenter": "us-east-1",
+			"vc_password":   "aws secret key",
+			"offer_vms":     1,
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: broker.providerConfig() + `
+data "leostream_center_ds" "test" {
+  id = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.leostream_center_ds.test", "id", "1"),
+					resource.TestCheckResourceAttr("data.leostream_center_ds.test", "center_definition.name", "aws-center-us-east-1"),
+					resource.TestCheckResourceAttr("data.leostream_center_ds.test", "center_definition.vc_datacenter", "us-east-1"),
+					resource.TestCheckResourceAttr("data.leostream_center_ds.test", "center_definition.offer_vms", "true"),
+					resource.TestCheckResourceAttr("data.leostream_center_ds.test", "center_definition.vc_password", CONFIG_MASKED_SECRET),
+					resource.TestCheckResourceAttr("data.leostream_center_ds.test", "center_info.aws_sizes.#", "2"),
+					resource.TestCheckResourceAttr("data.leostream_center_ds.test", "images.#", "1"),
+					resource.TestCheckResourceAttr("data.leostream_center_ds.test", "images.0.name", "ami-1"),
+				),
+			},
+		},
+	})
+}
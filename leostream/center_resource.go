@@ -5,16 +5,23 @@ package leostream
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"gitlab.hocmodo.nl/community/leostream-client-go"
@@ -22,9 +29,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &centerResource{}
-	_ resource.ResourceWithConfigure   = &centerResource{}
-	_ resource.ResourceWithImportState = &centerResource{}
+	_ resource.Resource                 = &centerResource{}
+	_ resource.ResourceWithConfigure    = &centerResource{}
+	_ resource.ResourceWithImportState  = &centerResource{}
+	_ resource.ResourceWithUpgradeState = &centerResource{}
 )
 
 // NewCenterResource is a helper function to simplify the provider implementation.
@@ -34,7 +42,7 @@ func NewCenterResource() resource.Resource {
 
 // centerResource is the resource implementation.
 type centerResource struct {
-	client *leostream.Client
+	client apiClient
 }
 
 // Metadata returns the resource type name.
@@ -45,6 +53,7 @@ func (r *centerResource) Metadata(_ context.Context, req resource.MetadataReques
 // Schema defines the schema for the resource.
 func (r *centerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     schemaVersion(centerStateMigrations),
 		Description: `The center resource allows you to create, read, update, and delete centers in Leostream.`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -67,11 +76,11 @@ func (r *centerResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						Required:    true,
 						Computed:    false,
 					},
-					"allow_rogue": schema.Int64Attribute{
+					"allow_rogue": schema.BoolAttribute{
 						Description: "Assign rogue users to desktops from this center.",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
 					"allow_rogue_policy_id": schema.Int64Attribute{
 						Description: "Policy for rogue users.",
@@ -79,23 +88,23 @@ func (r *centerResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						Computed:    true,
 						Default:     int64default.StaticInt64(0),
 					},
-					"continuous_autotag": schema.Int64Attribute{
+					"continuous_autotag": schema.BoolAttribute{
 						Description: "Apply auto-tags every time center is scanned.",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
-					"init_unavailable": schema.Int64Attribute{
+					"init_unavailable": schema.BoolAttribute{
 						Description: "Initialize desktops as unavailable.",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
-					"new_as_deletable": schema.Int64Attribute{
+					"new_as_deletable": schema.BoolAttribute{
 						Description: "New desktops are deletable.",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
 					"notes": schema.StringAttribute{
 						Description: "Notes for the center.",
@@ -103,17 +112,18 @@ func (r *centerResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						Computed:    true,
 						Default:     stringdefault.StaticString(""),
 					},
-					"offer_vms": schema.Int64Attribute{
+					"offer_vms": schema.BoolAttribute{
 						Description: "Offer VMs to users from this center.",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
 					"poll_interval": schema.Int64Attribute{
 						Description: "Interval in minutes to poll the center, 0 is don't poll.",
 						Optional:    true,
 						Computed:    true,
 						Default:     int64default.StaticInt64(0),
+						Validators:  []validator.Int64{int64validator.AtLeast(0)},
 					},
 					"proxy_address": schema.StringAttribute{
 						Description: "Proxy address for the center.",
@@ -126,18 +136,21 @@ func (r *centerResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						Optional:    true,
 						Computed:    true,
 						Default:     stringdefault.StaticString("amazon"),
+						Validators:  []validator.String{stringvalidator.OneOf(CONFIG_CENTER_TYPE_VALUES...)},
 					},
 					"vc_auth_method": schema.StringAttribute{
 						Description: "Authorization method: For Amazon centers, either Access Key or any attached IAM role: 'access_key' or 'attached_role'.",
 						Optional:    true,
 						Computed:    true,
 						Default:     stringdefault.StaticString(""),
+						Validators:  []validator.String{stringvalidator.OneOf(CONFIG_CENTER_VC_AUTH_METHOD_VALUES...)},
 					},
 					"vc_datacenter": schema.StringAttribute{
 						Description: "AWS region or a predefined value _custom if custom region is used.",
 						Optional:    true,
 						Computed:    true,
 						Default:     stringdefault.StaticString(""),
+						Validators:  []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(`^([a-z]{2}(-gov)?-[a-z]+-[0-9]+|_custom)?$`), "must be an AWS region like eu-west-1 or _custom")},
 					},
 					"vc_name": schema.StringAttribute{
 						Description: "The Access Key ID for a user with permission to access EC2.",
@@ -146,23 +159,25 @@ func (r *centerResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						Default:     stringdefault.StaticString(""),
 					},
 					"vc_password": schema.StringAttribute{
-						Description: "The Secret Access Key for the user.",
+						Description: "The Secret Access Key for the user. The API never returns the secret, the configured value is kept in state.",
 						Optional:    true,
-						Computed:    true,
 						Sensitive:   true,
-						Default:     stringdefault.StaticString("**********"),
 					},
-					"wait_inst_status": schema.Int64Attribute{
+					"vc_password_version": schema.Int64Attribute{
+						Description: "Version of vc_password. Change this value to send vc_password to Leostream again, e.g. to rotate a secret that was changed outside Terraform.",
+						Optional:    true,
+					},
+					"wait_inst_status": schema.BoolAttribute{
 						Description: "Wait for instance status to be running before assigning desktops.",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
-					"wait_sys_status": schema.Int64Attribute{
+					"wait_sys_status": schema.BoolAttribute{
 						Description: "Wait for system status to be valid before assigning desktops.",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
 				},
 			},
@@ -170,6 +185,11 @@ func (r *centerResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+// UpgradeState migrates state written by earlier schema versions.
+func (r *centerResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(centerStateMigrations)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *centerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -187,7 +207,7 @@ func (r *centerResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	r.client = client
+	r.client = leostreamAPI{client: client}
 }
 
 // Create a new resource.
@@ -205,21 +225,21 @@ func (r *centerResource) Create(ctx context.Context, req resource.CreateRequest,
 	var state centerResourceModel
 
 	CrStored := r.CreateNested(ctx, &plan, &state, &resp.Diagnostics)
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Map response body to schema and populate Computed attribute values
-	// convert int64 to string
-	plan.ID = types.StringValue(strconv.FormatInt(CrStored.Stored_data.ID, 10))
+	id := strconv.FormatInt(CrStored.Stored_data.ID, 10)
 
-	// set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
+	// Keep the ID of the new center in state first, so a failed read back leaves
+	// a tainted resource that Terraform replaces instead of an orphaned center
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// set state to the center as stored by the broker
+	r.readBack(ctx, id, &plan, req.Plan, &resp.State, &resp.Diagnostics)
 }
 
 // Read resource information.
@@ -240,9 +260,21 @@ func (r *centerResource) Read(ctx context.Context, req resource.ReadRequest, res
 	// // use common model for state
 	var newState centerResourceModel
 	// use common Read function
-	newState.Read(ctx, *r.client, &resp.Diagnostics, "resource", state.ID.ValueString())
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
+	found := newState.Read(ctx, r.client, &resp.Diagnostics, "resource", state.ID.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// remove the resource from state when it was deleted outside Terraform, so it is planned for creation
+	if !found {
+		tflog.Warn(ctx, "Leostream center not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// keep secrets from state, the API only returns them masked
+	newState.preserveSecrets(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -273,19 +305,39 @@ func (r *centerResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	_ = r.UpdateNested(ctx, &plan, &state, &resp.Diagnostics)
+	r.UpdateNested(ctx, &plan, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update state to the center as stored by the broker
+	r.readBack(ctx, plan.ID.ValueString(), &plan, req.Plan, &resp.State, &resp.Diagnostics)
+}
+
+// readBack reads the center from the Leostream API after a create or update and
+// sets the state, warning about attributes that were not stored as planned.
+// Secrets are taken from the plan, the API only returns them masked.
+func (r *centerResource) readBack(ctx context.Context, id string, planned *centerResourceModel, plan tfsdk.Plan, state *tfsdk.State, diags *diag.Diagnostics) {
+	var newState centerResourceModel
+	found := newState.Read(ctx, r.client, diags, "resource", id)
 	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
 		return
 	}
 
-	// update state
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	if !found {
+		diags.AddError(
+			"Unable to Read Back Center",
+			"Center "+id+" was not found in Leostream right after it was written.",
+		)
+		return
+	}
+
+	newState.preserveSecrets(ctx, planned, diags)
+	if diags.HasError() {
 		return
 	}
 
+	setReadBackState(ctx, "center", &newState, plan, state, diags)
 }
 
 func (r *centerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -300,7 +352,7 @@ func (r *centerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	// Delete existing center
 	err := r.client.DeleteCenter(state.ID.ValueString(), nil)
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting Leostream center",
 			"Could not delete center, unexpected error: "+err.Error(),
@@ -311,6 +363,17 @@ func (r *centerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *centerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// import by numeric ID or by name
+	importStateByIdOrName(ctx, req, resp, "center", func() ([]importCandidate, error) {
+		centers, err := r.client.GetCenters()
+		if err != nil {
+			return nil, err
+		}
+
+		var candidates []importCandidate
+		for _, center := range centers {
+			candidates = append(candidates, importCandidate{ID: int64(center.ID), Name: center.Name})
+		}
+		return candidates, nil
+	})
 }
@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCenterResource(t *testing.T) {
+	broker := newTestBroker(t)
+
+	config := func(notes string, password string) string {
+		return broker.providerConfig() + fmt.Sprintf(`
+resource "leostream_center" "test" {
+  center_definition = {
+    name             = "aws-center-us-east-1"
+    type             = "amazon"
+    vc_datacenter    = "us-east-1"
+    vc_name          = "aws access key"
+    vc_password      = %q
+    vc_auth_method   = "access_key"
+    wait_inst_status = true
+    notes            = %q
+  }
+}
+`, password, notes)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config("", "aws secret key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_center.test", "id", "1"),
+					resource.TestCheckResourceAttr("leostream_center.test", "center_definition.name", "aws-center-us-east-1"),
+					resource.TestCheckResourceAttr("leostream_center.test", "center_definition.wait_inst_status", "true"),
+					resource.TestCheckResourceAttr("leostream_center.test", "center_definition.wait_sys_status", "false"),
+					// the broker returns the password masked, the configured value is kept
+					resource.TestCheckResourceAttr("leostream_center.test", "center_definition.vc_password", "aws secret key"),
+					broker.checkCenterPassword(1, "aws secret key"),
+				),
+			},
+			// ImportState testing, secrets can not be imported
+			{
+				ResourceName:            "leostream_center.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"center_definition.vc_password", "center_definition.vc_password_version"},
+			},
+			// Update without changing the password
+			{
+				Config: config("updated", "aws secret key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_center.test", "center_definition.notes", "updated"),
+					broker.checkCenterPassword(1, "aws secret key"),
+				),
+			},
+			// Rotate the password
+			{
+				Config: config("updated", "rotated secret key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_center.test", "center_definition.vc_password", "rotated secret key"),
+					broker.checkCenterPassword(1, "rotated secret key"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: broker.checkDestroyed("centers"),
+	})
+}
+
+// The broker never returns vc_password, so an imported center has no password in
+// state and the first plan re-sends the configured password once. Importing into
+// an empty state needs a removed block, Terraform 1.7 or newer.
+func TestAccCenterResourceImportPassword(t *testing.T) {
+	broker := newTestBroker(t)
+
+	config := broker.providerConfig() + `
+resource "leostream_center" "test" {
+  center_definition = {
+    name           = "aws-center-us-east-1"
+    type           = "amazon"
+    vc_datacenter  = "us-east-1"
+    vc_name        = "aws access key"
+    vc_password    = "aws secret key"
+    vc_auth_method = "access_key"
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// Forget the center without destroying it
+			{
+				Config: testAccRemovedConfig(broker, "leostream_center.test"),
+			},
+			// Import the center into the empty state
+			{
+				Config:             config,
+				ResourceName:       "leostream_center.test",
+				ImportState:        true,
+				ImportStateId:      "1",
+				ImportStatePersist: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("leostream_center.test", "center_definition.vc_password"),
+				),
+			},
+			// The first plan after import updates the password in place
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("leostream_center.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_center.test", "center_definition.vc_password", "aws secret key"),
+					broker.checkCenterPassword(1, "aws secret key"),
+				),
+			},
+			// After that the plan is empty
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+		CheckDestroy: broker.checkDestroyed("centers"),
+	})
+}
+
+// checkCenterPassword checks the password stored on the broker for a center.
+func (b *testBroker) checkCenterPassword(id int64, want string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		center, ok := b.get("centers", id)
+		if !ok {
+			return fmt.Errorf("center %d not found on the broker", id)
+		}
+		definition, _ := center["center_definition"].(map[string]any)
+		if got := definition["vc_password"]; got != want {
+			return fmt.Errorf("center %d has password %q on the broker, want %q", id, got, want)
+		}
+		return nil
+	}
+}
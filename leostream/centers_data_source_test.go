@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCentersDataSource(t *testing.T) {
+	broker := newTestBroker(t)
+	broker.put("centers", map[string]any{"center_definition": map[string]any{"name": "aws-center-us-east-1", "type": "amazon"}})
+	broker.put("centers", map[string]any{"center_definition": map[string]any{"name": "vcenter", "type": "vcenter"}})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: broker.providerConfig() + `
+data "leostream_centers" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.leostream_centers.test", "centers.#", "2"),
+					resource.TestCheckResourceAttr("data.leostream_centers.test", "centers.0.id", "1"),
+					resource.TestCheckResourceAttr("data.leostream_centers.test", "centers.0.name", "aws-center-us-east-1"),
+					resource.TestCheckResourceAttr("data.leostream_centers.test", "centers.0.center_type", "amazon"),
+					resource.TestCheckResourceAttr("data.leostream_centers.test", "centers.1.id", "2"),
+					resource.TestCheckResourceAttr("data.leostream_centers.test", "centers.1.name", "vcenter"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,277 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab.hocmodo.nl/community/leostream-client-go"
+)
+
+// apiClient is the part of the Leostream client used by the resources. The
+// resources depend on this interface instead of *leostream.Client, so they can
+// be tested against a fake implementation.
+type apiClient interface {
+	GetPools() ([]leostream.PoolList, error)
+	GetPool(id string) (*leostream.Pool, error)
+	CreatePool(pool leostream.Pool, token *string) (*leostream.PoolsStored, error)
+	UpdatePool(id string, pool leostream.Pool, token *string) (*leostream.PoolsStored, error)
+	DeletePool(id string, token *string) error
+
+	GetCenters() ([]leostream.CenterList, error)
+	GetCenter(id string) (*leostream.Center, error)
+	CreateCenter(center leostream.Center, token *string) (*leostream.CenterStored, error)
+	UpdateCenter(id string, center leostream.Center, token *string) (*leostream.CenterStored, error)
+	DeleteCenter(id string, token *string) error
+
+	GetGateways() ([]leostream.Gateway, error)
+	GetGateway(id string) (*leostream.Gateway, error)
+	CreateGateway(gateway leostream.Gateway, token *string) (*leostream.GatewayStored, error)
+	UpdateGateway(id string, gateway leostream.Gateway, token *string) (*leostream.GatewayStored, error)
+	DeleteGateway(id string, token *string) error
+}
+
+var _ apiClient = leostreamAPI{}
+
+// leostreamAPI is the apiClient of the resources. It wraps the shared
+// *leostream.Client and returns the error responses of the API as *apiError.
+type leostreamAPI struct {
+	client *leostream.Client
+}
+
+func (a leostreamAPI) GetPools() ([]leostream.PoolList, error) {
+	pools, err := a.client.GetPools()
+	return pools, newAPIError(err)
+}
+
+func (a leostreamAPI) GetPool(id string) (*leostream.Pool, error) {
+	pool, err := a.client.GetPool(id)
+	return pool, newAPIError(err)
+}
+
+func (a leostreamAPI) CreatePool(pool leostream.Pool, token *string) (*leostream.PoolsStored, error) {
+	stored, err := a.client.CreatePool(pool, token)
+	return stored, newAPIError(err)
+}
+
+func (a leostreamAPI) UpdatePool(id string, pool leostream.Pool, token *string) (*leostream.PoolsStored, error) {
+	stored, err := a.client.UpdatePool(id, pool, token)
+	return stored, newAPIError(err)
+}
+
+func (a leostreamAPI) DeletePool(id string, token *string) error {
+	return newAPIError(a.client.DeletePool(id, token))
+}
+
+func (a leostreamAPI) GetCenters() ([]leostream.CenterList, error) {
+	centers, err := a.client.GetCenters()
+	return centers, newAPIError(err)
+}
+
+func (a leostreamAPI) GetCenter(id string) (*leostream.Center, error) {
+	center, err := a.client.GetCenter(id)
+	return center, newAPIError(err)
+}
+
+func (a leostreamAPI) CreateCenter(center leostream.Center, token *string) (*leostream.CenterStored, error) {
+	stored, err := a.client.CreateCenter(center, token)
+	return stored, newAPIError(err)
+}
+
+func (a leostreamAPI) UpdateCenter(id string, center leostream.Center, token *string) (*leostream.CenterStored, error) {
+	stored, err := a.client.UpdateCenter(id, center, token)
+	return stored, newAPIError(err)
+}
+
+func (a leostreamAPI) DeleteCenter(id string, token *string) error {
+	return newAPIError(a.client.DeleteCenter(id, token))
+}
+
+func (a leostreamAPI) GetGateways() ([]leostream.Gateway, error) {
+	gateways, err := a.client.GetGateways()
+	return gateways, newAPIError(err)
+}
+
+func (a leostreamAPI) GetGateway(id string) (*leostream.Gateway, error) {
+	gateway, err := a.client.GetGateway(id)
+	return gateway, newAPIError(err)
+}
+
+func (a leostreamAPI) CreateGateway(gateway leostream.Gateway, token *string) (*leostream.GatewayStored, error) {
+	stored, err := a.client.CreateGateway(gateway, token)
+	return stored, newAPIError(err)
+}
+
+func (a leostreamAPI) UpdateGateway(id string, gateway leostream.Gateway, token *string) (*leostream.GatewayStored, error) {
+	stored, err := a.client.UpdateGateway(id, gateway, token)
+	return stored, newAPIError(err)
+}
+
+func (a leostreamAPI) DeleteGateway(id string, token *string) error {
+	return newAPIError(a.client.DeleteGateway(id, token))
+}
+
+// newLeostreamClient creates the shared Leostream client with the given transport.
+// The client is created without credentials first, so the login request itself
+// also goes through the transport (TLS, proxy, throttling).
+//
+// When a pre-issued session token is given it is used as is, otherwise the client
+// logs in with the username and password. In both cases an expired session is
+// renewed once per failing request, as long as a username and password are known.
+func newLeostreamClient(ctx context.Context, host string, username string, password string, token string, transport http.RoundTripper) (*leostream.Client, error) {
+	client, err := leostream.NewClient(&host, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.HTTPClient == nil {
+		client.HTTPClient = &http.Client{}
+	}
+
+	session := &sessionTransport{
+		ctx:  ctx,
+		base: transport,
+	}
+
+	if username != "" && password != "" {
+		client.Auth = leostream.AuthStruct{
+			Username: username,
+			Password: password,
+		}
+
+		// Log in with a copy of the client that bypasses the session handling,
+		// so a failed login is never retried by the session transport itself.
+		loginClient := *client
+		loginClient.HTTPClient = &http.Client{
+			Timeout:   client.HTTPClient.Timeout,
+			Transport: transport,
+		}
+		session.login = func() (string, error) {
+			auth, err := loginClient.SignIn()
+			if err != nil {
+				return "", fmt.Errorf("unable to log in to %s: %w", host, err)
+			}
+			return auth.Token, nil
+		}
+	}
+
+	client.HTTPClient.Transport = session
+
+	if token != "" {
+		tflog.Debug(ctx, "Using pre-issued Leostream session token")
+	} else if session.login == nil {
+		return nil, fmt.Errorf("either a session token or a username and password are required")
+	} else if token, err = session.login(); err != nil {
+		return nil, err
+	}
+
+	// client.Token keeps the first session token, it is never written again. The
+	// session transport sets the current token on every request instead.
+	client.Token = token
+	session.token = token
+
+	return client, nil
+}
+
+// sessionTransport is an http.RoundTripper that sends every request with the
+// current session token, renews the session when the broker reports it as
+// expired, and retries the failed request once with the new session token.
+type sessionTransport struct {
+	// ctx carries the provider logger, the requests made by the Leostream
+	// client do not carry a context of their own.
+	ctx  context.Context
+	base http.RoundTripper
+
+	// login returns a new session token, nil when only a pre-issued token is known
+	login func() (string, error)
+
+	// mu guards token, which is shared by all concurrent requests
+	mu    sync.Mutex
+	token string
+}
+
+// RoundTrip sends the request and, on a 401 response, logs in again and retries once.
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := t.currentToken()
+	resp, err := t.base.RoundTrip(withToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || t.login == nil {
+		return resp, err
+	}
+
+	// The request body was consumed and cannot be sent again
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	// Release the response before logging in, the transports below hold resources
+	// until the body is closed (e.g. the last free slot of max_concurrent_requests).
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	tflog.Info(t.ctx, "Leostream session expired, logging in again", map[string]any{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	})
+
+	token, err = t.renew(token)
+	if err != nil {
+		tflog.Warn(t.ctx, "Unable to renew the Leostream session", map[string]any{"error": err.Error()})
+		return resp, nil
+	}
+
+	retry := withToken(req, token)
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+	}
+
+	return t.base.RoundTrip(retry)
+}
+
+// currentToken returns the session token to send.
+func (t *sessionTransport) currentToken() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.token
+}
+
+// renew logs in again, unless another request already renewed the session
+// that was used by the failing request.
+func (t *sessionTransport) renew(usedToken string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != usedToken {
+		return t.token, nil
+	}
+
+	token, err := t.login()
+	if err != nil {
+		return "", err
+	}
+	t.token = token
+
+	return token, nil
+}
+
+// withToken returns a copy of the request with the session token in the
+// Authorization header, a RoundTripper must not modify the original.
+func withToken(req *http.Request, token string) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", token)
+
+	return req
+}
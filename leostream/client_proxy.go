@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// buildProxyFunc returns the proxy selection function for the Leostream client.
+// Requests to hosts matching one of the noProxy entries bypass the proxy, the
+// entries follow the same rules as the NO_PROXY environment variable.
+func buildProxyFunc(proxyURL string, noProxy []string) (func(*http.Request) (*url.URL, error), error) {
+	// The proxy URL may hold a password, errors only show it redacted
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %s: expected a URL like http://proxy.example.com:3128", parsed.Redacted())
+	}
+
+	proxyConfig := httpproxy.Config{
+		HTTPProxy:  proxyURL,
+		HTTPSProxy: proxyURL,
+		NoProxy:    strings.Join(noProxy, ","),
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
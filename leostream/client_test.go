@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// sessionServer is a broker that accepts only the token of its latest login.
+type sessionServer struct {
+	mu     sync.Mutex
+	token  string
+	logins atomic.Int64
+}
+
+func (s *sessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/rest/v1/session/login" {
+		s.mu.Lock()
+		s.token = fmt.Sprintf("session-%d", s.logins.Add(1))
+		token := s.token
+		s.mu.Unlock()
+		fmt.Fprintf(w, `{"sid": %q}`, token)
+		return
+	}
+
+	s.mu.Lock()
+	valid := r.Header.Get("Authorization") == s.token
+	s.mu.Unlock()
+	if !valid {
+		http.Error(w, `{"error": "session expired"}`, http.StatusUnauthorized)
+		return
+	}
+	fmt.Fprint(w, `[]`)
+}
+
+func TestSessionTransportRenewWithOneSlot(t *testing.T) {
+	server := httptest.NewServer(&sessionServer{})
+	defer server.Close()
+
+	// The login on a 401 needs the only slot of the throttled transport, the 401 response must free it first
+	transport := newThrottledTransport(context.Background(), http.DefaultTransport, 0, 1)
+	client, err := newLeostreamClient(context.Background(), server.URL, "admin", "secret", "expired", transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetPools()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected the request to succeed after renewing the session, got: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request did not finish, the 401 response still holds the concurrency slot")
+	}
+}
+
+func TestSessionTransportConcurrentRenew(t *testing.T) {
+	broker := &sessionServer{}
+	server := httptest.NewServer(broker)
+	defer server.Close()
+
+	client, err := newLeostreamClient(context.Background(), server.URL, "admin", "secret", "", http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Expire the session, every request below gets a 401 for the token it sends
+	broker.mu.Lock()
+	broker.token = "expired"
+	broker.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetPools(); err != nil {
+				t.Errorf("expected the request to succeed after renewing the session, got: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// One login in newLeostreamClient, and one renewal shared by all requests
+	if logins := broker.logins.Load(); logins != 2 {
+		t.Errorf("expected 2 logins, got %d", logins)
+	}
+}
+
+func TestSessionTransportPreIssuedToken(t *testing.T) {
+	broker := &sessionServer{token: "pre-issued"}
+	server := httptest.NewServer(broker)
+	defer server.Close()
+
+	client, err := newLeostreamClient(context.Background(), server.URL, "", "", "pre-issued", http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetPools(); err != nil {
+		t.Errorf("expected the request to succeed with the pre-issued token, got: %s", err)
+	}
+
+	// Without credentials an expired token is not renewed
+	broker.mu.Lock()
+	broker.token = "other"
+	broker.mu.Unlock()
+
+	if _, err := client.GetPools(); err == nil {
+		t.Error("expected the request to fail without credentials to renew the session")
+	}
+	if logins := broker.logins.Load(); logins != 0 {
+		t.Errorf("expected no logins, got %d", logins)
+	}
+}
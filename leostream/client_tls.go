@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// tlsSettings holds the resolved TLS options of the provider configuration.
+type tlsSettings struct {
+	CaCertFile         string
+	CaCertPem          string
+	ClientCertFile     string
+	ClientKeyFile      string
+	ClientCertPem      string
+	ClientKeyPem       string
+	InsecureSkipVerify bool
+}
+
+// isDefault reports whether no TLS option was set, so the default transport can be used.
+func (s tlsSettings) isDefault() bool {
+	return s == tlsSettings{}
+}
+
+// buildTLSConfig converts the TLS settings into a tls.Config for the Leostream client.
+func buildTLSConfig(s tlsSettings) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// #nosec G402 -- only enabled on explicit request of the practitioner
+		InsecureSkipVerify: s.InsecureSkipVerify,
+	}
+
+	// Add the internal CA to the system pool, so public certificates keep working
+	if s.CaCertFile != "" || s.CaCertPem != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if s.CaCertFile != "" {
+			pem, err := os.ReadFile(s.CaCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read CA certificate file %s: %w", s.CaCertFile, err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid PEM certificates found in CA certificate file %s", s.CaCertFile)
+			}
+		}
+
+		if s.CaCertPem != "" {
+			if !pool.AppendCertsFromPEM([]byte(s.CaCertPem)) {
+				return nil, fmt.Errorf("no valid PEM certificates found in ca_cert_pem")
+			}
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	// Client certificate for mutual TLS, either from files or inline PEM
+	certPem, keyPem := []byte(s.ClientCertPem), []byte(s.ClientKeyPem)
+	if s.ClientCertFile != "" {
+		pem, err := os.ReadFile(s.ClientCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client certificate file %s: %w", s.ClientCertFile, err)
+		}
+		certPem = pem
+	}
+	if s.ClientKeyFile != "" {
+		pem, err := os.ReadFile(s.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client key file %s: %w", s.ClientKeyFile, err)
+		}
+		keyPem = pem
+	}
+
+	if len(certPem) > 0 || len(keyPem) > 0 {
+		if len(certPem) == 0 || len(keyPem) == 0 {
+			return nil, fmt.Errorf("both a client certificate and a client key are required for mutual TLS")
+		}
+		cert, err := tls.X509KeyPair(certPem, keyPem)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate and key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
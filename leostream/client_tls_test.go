@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificate is a generated certificate with its PEM encoded key.
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPem string
+	keyPem  string
+}
+
+// newTestCertificate generates a certificate signed by parent, or a self-signed CA when parent is nil.
+func newTestCertificate(t *testing.T, name string, parent *testCertificate) testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testCertificate{
+		cert:    cert,
+		key:     key,
+		certPem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPem:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})),
+	}
+}
+
+// writeTestFile writes content to a file in dir and returns its path.
+func writeTestFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+
+	file := filepath.Join(dir, name)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestBuildTLSConfig(t *testing.T) {
+	ca := newTestCertificate(t, "Leostream Test CA", nil)
+	broker := newTestCertificate(t, "broker.example.com", &ca)
+	client := newTestCertificate(t, "terraform", &ca)
+	other := newTestCertificate(t, "other", &ca)
+
+	dir := t.TempDir()
+	caFile := writeTestFile(t, dir, "ca.pem", ca.certPem)
+	certFile := writeTestFile(t, dir, "client.pem", client.certPem)
+	keyFile := writeTestFile(t, dir, "client-key.pem", client.keyPem)
+	missing := filepath.Join(dir, "missing.pem")
+
+	tests := map[string]struct {
+		settings tlsSettings
+		// wantErr is part of the expected error, empty when no error is expected
+		wantErr string
+		// wantCA expects the broker certificate to verify against the root CAs
+		wantCA bool
+		// wantClientCert expects a client certificate for mutual TLS
+		wantClientCert bool
+	}{
+		"insecure skip verify": {
+			settings: tlsSettings{InsecureSkipVerify: true},
+		},
+		"CA from PEM": {
+			settings: tlsSettings{CaCertPem: ca.certPem},
+			wantCA:   true,
+		},
+		"CA from file": {
+			settings: tlsSettings{CaCertFile: caFile},
+			wantCA:   true,
+		},
+		"CA PEM without certificates": {
+			settings: tlsSettings{CaCertPem: "not a certificate"},
+			wantErr:  "no valid PEM certificates found in ca_cert_pem",
+		},
+		"CA file without certificates": {
+			settings: tlsSettings{CaCertFile: keyFile},
+			wantErr:  "no valid PEM certificates found in CA certificate file",
+		},
+		"CA file missing": {
+			settings: tlsSettings{CaCertFile: missing},
+			wantErr:  "unable to read CA certificate file",
+		},
+		"client certificate from PEM": {
+			settings:       tlsSettings{ClientCertPem: client.certPem, ClientKeyPem: client.keyPem},
+			wantClientCert: true,
+		},
+		"client certificate from files": {
+			settings:       tlsSettings{ClientCertFile: certFile, ClientKeyFile: keyFile},
+			wantClientCert: true,
+		},
+		"client certificate from file, key from PEM": {
+			settings:       tlsSettings{ClientCertFile: certFile, ClientKeyPem: client.keyPem},
+			wantClientCert: true,
+		},
+		"client certificate without key": {
+			settings: tlsSettings{ClientCertPem: client.certPem},
+			wantErr:  "both a client certificate and a client key are required",
+		},
+		"client key without certificate": {
+			settings: tlsSettings{ClientKeyFile: keyFile},
+			wantErr:  "both a client certificate and a client key are required",
+		},
+		"client key of another certificate": {
+			settings: tlsSettings{ClientCertPem: client.certPem, ClientKeyPem: other.keyPem},
+			wantErr:  "unable to load client certificate and key",
+		},
+		"client certificate file missing": {
+			settings: tlsSettings{ClientCertFile: missing, ClientKeyFile: keyFile},
+			wantErr:  "unable to read client certificate file",
+		},
+		"client key file missing": {
+			settings: tlsSettings{ClientCertFile: certFile, ClientKeyFile: missing},
+			wantErr:  "unable to read client key file",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := buildTLSConfig(test.settings)
+
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected an error containing %q, got: %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if config.MinVersion != tls.VersionTLS12 {
+				t.Errorf("got minimum TLS version %x, want TLS 1.2", config.MinVersion)
+			}
+			if config.InsecureSkipVerify != test.settings.InsecureSkipVerify {
+				t.Errorf("got InsecureSkipVerify %t, want %t", config.InsecureSkipVerify, test.settings.InsecureSkipVerify)
+			}
+
+			if test.wantCA {
+				if config.RootCAs == nil {
+					t.Fatal("expected root CAs")
+				}
+				if _, err := broker.cert.Verify(x509.VerifyOptions{Roots: config.RootCAs, DNSName: "broker.example.com"}); err != nil {
+					t.Errorf("broker certificate does not verify against the configured CA: %s", err)
+				}
+			} else if config.RootCAs != nil {
+				t.Error("expected the system root CAs, got a custom pool")
+			}
+
+			if got := len(config.Certificates); test.wantClientCert && got != 1 || !test.wantClientCert && got != 0 {
+				t.Errorf("got %d client certificates, want client certificate %t", got, test.wantClientCert)
+			}
+		})
+	}
+}
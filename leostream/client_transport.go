@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// throttledTransport is an http.RoundTripper that limits the rate and the
+// number of concurrent requests sent to the Leostream REST API. It is shared by
+// every resource and data source through the single client created in Configure.
+type throttledTransport struct {
+	// ctx carries the provider logger, the requests made by the Leostream
+	// client do not carry a context of their own.
+	ctx  context.Context
+	base http.RoundTripper
+
+	// interval is the minimum time between the start of two requests, 0 disables rate limiting
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+
+	// slots limits the number of in-flight requests, nil disables the limit
+	slots chan struct{}
+}
+
+// newThrottledTransport wraps base with rate limiting and a concurrency cap.
+// A requestsPerSecond or maxConcurrent of 0 disables the respective limit.
+func newThrottledTransport(ctx context.Context, base http.RoundTripper, requestsPerSecond float64, maxConcurrent int64) *throttledTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	t := &throttledTransport{
+		ctx:  ctx,
+		base: base,
+	}
+
+	if requestsPerSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	return t
+}
+
+// RoundTrip waits for a free slot and for the rate limit before sending the request.
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		default:
+			tflog.Debug(t.ctx, "Leostream API concurrency limit reached, waiting for a free slot", map[string]any{
+				"max_concurrent_requests": cap(t.slots),
+				"method":                  req.Method,
+				"path":                    req.URL.Path,
+			})
+			select {
+			case t.slots <- struct{}{}:
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			}
+		}
+	}
+
+	resp, err := t.send(req)
+	if err != nil || t.slots == nil {
+		if t.slots != nil {
+			<-t.slots
+		}
+		return resp, err
+	}
+
+	// keep the slot until the caller is done with the response body
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { <-t.slots }}
+
+	return resp, nil
+}
+
+// send applies the rate limit before handing the request to the base transport.
+func (t *throttledTransport) send(req *http.Request) (*http.Response, error) {
+	if wait := t.reserve(); wait > 0 {
+		tflog.Debug(t.ctx, "Leostream API rate limit reached, delaying request", map[string]any{
+			"delay":  wait.String(),
+			"method": req.Method,
+			"path":   req.URL.Path,
+		})
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// releasingBody frees a concurrency slot once the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close closes the underlying body and releases the slot exactly once.
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// reserve claims the next free send time and returns how long to wait for it.
+func (t *throttledTransport) reserve() time.Duration {
+	if t.interval == 0 {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	wait := t.next.Sub(now)
+	t.next = t.next.Add(t.interval)
+
+	return wait
+}
+
+// headerTransport is an http.RoundTripper that adds the configured custom
+// headers to every request sent to the Leostream REST API.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+// newHeaderTransport wraps base so every request carries the given headers.
+func newHeaderTransport(base http.RoundTripper, headers http.Header) *headerTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &headerTransport{
+		base:    base,
+		headers: headers,
+	}
+}
+
+// RoundTrip adds the custom headers to a copy of the request, a RoundTripper must not modify the original.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		req.Header[name] = values
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCountingServer returns a server that counts the requests in flight. Each
+// request waits for release, when it is set, before it is answered.
+func newCountingServer(t *testing.T, release chan struct{}) (*httptest.Server, *atomic.Int64, *atomic.Int64) {
+	t.Helper()
+
+	var inFlight, maxInFlight atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		if release != nil {
+			<-release
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &inFlight, &maxInFlight
+}
+
+func TestThrottledTransportRateInterval(t *testing.T) {
+	server, _, _ := newCountingServer(t, nil)
+
+	// 20 requests per second is one request every 50ms
+	client := &http.Client{Transport: newThrottledTransport(context.Background(), http.DefaultTransport, 20, 0)}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	// The first request is sent right away, the next four wait one interval each
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("5 requests at 20 per second took %s, want at least 200ms", elapsed)
+	}
+}
+
+func TestThrottledTransportConcurrencyCap(t *testing.T) {
+	release := make(chan struct{})
+	server, inFlight, maxInFlight := newCountingServer(t, release)
+
+	client := &http.Client{Transport: newThrottledTransport(context.Background(), http.DefaultTransport, 0, 2)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+
+	// Wait until the first two requests reach the server, the others wait for a slot
+	deadline := time.Now().Add(5 * time.Second)
+	for inFlight.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got != 2 {
+		t.Errorf("got at most %d requests in flight, want 2", got)
+	}
+}
+
+func TestThrottledTransportReleasesSlotOnClose(t *testing.T) {
+	server, _, _ := newCountingServer(t, nil)
+
+	transport := newThrottledTransport(context.Background(), http.DefaultTransport, 0, 1)
+	client := &http.Client{Transport: transport}
+
+	first, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The slot is held until the body of the first response is closed
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the second request to wait for the slot, got: %v", err)
+	}
+
+	// Closing twice releases the slot only once
+	first.Body.Close()
+	first.Body.Close()
+
+	second, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second.Body.Close()
+
+	if got := len(transport.slots); got != 0 {
+		t.Errorf("got %d slots in use after all bodies were closed, want 0", got)
+	}
+}
+
+func TestThrottledTransportContextCancel(t *testing.T) {
+	server, _, _ := newCountingServer(t, nil)
+
+	// One request per minute, the second request has to wait for the rate limit
+	transport := newThrottledTransport(context.Background(), http.DefaultTransport, 1.0/60, 1)
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	if _, err := client.Do(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancelled request to fail with context.Canceled, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancelled request returned after %s", elapsed)
+	}
+
+	// The cancelled request gave its slot back
+	if got := len(transport.slots); got != 0 {
+		t.Errorf("got %d slots in use after the cancelled request, want 0", got)
+	}
+}
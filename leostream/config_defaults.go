@@ -10,11 +10,11 @@ var CONFIG_POOL_SERVERIDS = []int64{0}
 var CONFIG_POOL_PROVISION_THRESHOLD = int64(0)
 var CONFIG_POOL_PROVISION_TENANT_ID = int64(0)
 var CONFIG_POOL_PROVISION_VM_NAME_NEXT_VALUE = int64(0)
-var CONFIG_POOL_PROVISION_LIMITS_ENFORCE = int64(0)
-var CONFIG_POOL_MARK_DELETABLE = int64(0)
+var CONFIG_POOL_PROVISION_LIMITS_ENFORCE = false
+var CONFIG_POOL_MARK_DELETABLE = false
 
 // Pool provisioning
-var CONFIG_POOL_PROVISION_ON_OFF = int64(0)
+var CONFIG_POOL_PROVISION_ON_OFF = false
 var CONFIG_POOL_PROVISION_MAX = int64(0)
 var CONFIG_POOL_PROVISION_VM_ID = int64(0)
 
@@ -24,3 +24,73 @@ var CONFIG_POOL_PROVISION_SERVER_ID = int64(0)
 // Poolassignment defaults
 var CONFIG_OFFER_FILTER_JOIN = "O"
 var CONFIG_OFFER_FILTER_LIST = []string{""}
+
+// Secrets
+// CONFIG_MASKED_SECRET is returned by the Leostream API instead of the value of a stored secret
+const CONFIG_MASKED_SECRET = "**********"
+
+// Accepted values of enumerated fields
+// Pool definition restrict_by: attribute, tag, centers, vSphere hosts, vSphere clusters, vSphere resource pools, LDAP attributes, ad hoc list
+var CONFIG_POOL_RESTRICT_BY_VALUES = []string{"A", "T", "C", "E", "L", "V", "Z", "H"}
+
+// Pool definition restrict_by descriptions
+var CONFIG_POOL_RESTRICT_BY_LABELS = map[string]string{
+	"A": "by attribute",
+	"T": "by tag",
+	"C": "by centers",
+	"E": "vSphere hosts",
+	"L": "vSphere clusters",
+	"V": "vSphere resource pools",
+	"Z": "LDAP attributes",
+	"H": "ad hoc list (selection from parent pool)",
+}
+
+// Pool definition restrict_by values that select desktops by the attributes list
+var CONFIG_POOL_RESTRICT_BY_ATTRIBUTES = []string{"A", "Z"}
+
+// Pool definition pool_attribute_join: and, or
+var CONFIG_POOL_ATTRIBUTE_JOIN_VALUES = []string{"A", "O"}
+
+// Pool attribute condition_type
+var CONFIG_POOL_CONDITION_TYPE_VALUES = []string{"ip", "np", "eq", "ne", "gt", "lt", "ct", "nc", "bw", "ew"}
+
+// Pool attribute condition_type values that compare against an IP address or CIDR notation
+var CONFIG_POOL_CONDITION_TYPE_CIDR_VALUES = []string{"ip", "np"}
+
+// Pool attribute field prefixes of the pool_attribute function, for an LDAP attribute or a vm_gpu field instead of a vm table field
+const CONFIG_POOL_ATTRIBUTE_AD_PREFIX = "ad:"
+const CONFIG_POOL_ATTRIBUTE_GPU_PREFIX = "gpu:"
+
+// Pool attribute vm_table_field, empty when ad_attribute_field or vm_gpu_field is used
+var CONFIG_POOL_VM_TABLE_FIELD_VALUES = []string{
+	"", "name", "display_name", "windows_name", "ip", "partition_names", "partition_mount_points", "guest_os",
+	"os_version", "installed_protocols", "vc_memory_mb", "vc_num_cpu", "vc_num_ethernet_cards", "num_disks",
+	"computer_model", "bios_serial_number", "max_clock_speed", "notes", "vc_annotation", "tag_filter", "server_id",
+}
+
+// Pool provisioning vm_name placeholder for the sequence number
+const CONFIG_POOL_VM_NAME_SEQUENCE = "{SEQUENCE}"
+
+// Pool provisioning center provision_method
+var CONFIG_POOL_PROVISION_METHOD_VALUES = []string{"image"}
+
+// Center type, the provider currently only supports AWS centers
+const CONFIG_CENTER_TYPE_AMAZON = "amazon"
+
+var CONFIG_CENTER_TYPE_VALUES = []string{CONFIG_CENTER_TYPE_AMAZON}
+
+// Center vc_auth_method, empty when not applicable
+var CONFIG_CENTER_VC_AUTH_METHOD_VALUES = []string{"", "access_key", "attached_role"}
+
+// Gateway defaults, the broker stores an omitted field with these values
+const CONFIG_GATEWAY_ADDRESS_PRIVATE = ""
+const CONFIG_GATEWAY_LOAD_BALANCER_ID = int64(0)
+const CONFIG_GATEWAY_USE_SRC_IP = int64(0)
+const CONFIG_GATEWAY_NOTES = ""
+
+// Gateway use_src_ip: random port, same port, source IP filtering with random port
+const CONFIG_GATEWAY_USE_SRC_IP_MIN = int64(0)
+const CONFIG_GATEWAY_USE_SRC_IP_MAX = int64(2)
+
+// Import identifier prefix that marks a name instead of a numeric ID, e.g. "name:AWS desktop pool 2"
+const CONFIG_IMPORT_NAME_PREFIX = "name:"
@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// setReadBackState sets the state after a create or update from the plan and the
+// object read back from the Leostream API. Terraform rejects a state that differs
+// from a known planned value as an inconsistent result, so the state keeps the
+// planned values and a mismatch is only reported as a warning. Values that are
+// unknown in the plan, e.g. the ID of a new object, are taken from the object
+// read back.
+func setReadBackState(ctx context.Context, kind string, readBack any, plan tfsdk.Plan, state *tfsdk.State, diags *diag.Diagnostics) {
+	stored := tfsdk.State{Schema: state.Schema}
+	diags.Append(stored.Set(ctx, readBack)...)
+	if diags.HasError() {
+		return
+	}
+
+	warnOnDrift(ctx, kind, plan, stored, diags)
+
+	raw, err := plannedState(plan.Raw, stored.Raw)
+	if err != nil {
+		diags.AddError(
+			"Unable to Set Leostream "+kind+" State",
+			"Could not combine the plan with the "+kind+" read back from Leostream: "+err.Error(),
+		)
+		return
+	}
+	state.Raw = raw
+}
+
+// plannedState returns the planned value with every unknown value replaced by
+// the value at the same path of the stored value.
+func plannedState(planned tftypes.Value, stored tftypes.Value) (tftypes.Value, error) {
+	return tftypes.Transform(planned, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if v.IsKnown() {
+			return v, nil
+		}
+
+		value, _, err := tftypes.WalkAttributePath(stored, p)
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("%s: %w", attributePathString(p), err)
+		}
+		storedValue, ok := value.(tftypes.Value)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("%s: unexpected %T", attributePathString(p), value)
+		}
+		return storedValue, nil
+	})
+}
+
+// warnOnDrift adds a warning listing the attributes whose value, as read back
+// from the Leostream API after a create or update, differs from the plan. This
+// happens when the broker normalises or ignores part of a change.
+func warnOnDrift(ctx context.Context, kind string, plan tfsdk.Plan, stored tfsdk.State, diags *diag.Diagnostics) {
+	mismatches, err := driftedAttributes(ctx, plan, stored)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to compare the plan with the state read back from Leostream", map[string]any{"error": err.Error()})
+		return
+	}
+
+	if len(mismatches) == 0 {
+		return
+	}
+
+	diags.AddWarning(
+		"Leostream "+kind+" differs from the plan",
+		fmt.Sprintf("The %s read back from Leostream after the change does not match the plan. "+
+			"The broker may have normalised or rejected part of the change:\n\n%s\n\n"+
+			"The state keeps the planned values, the next plan shows the difference with the values stored by the broker. "+
+			"Update the configuration to the stored values to resolve this.",
+			kind, strings.Join(mismatches, "\n")),
+	)
+}
+
+// driftedAttributes returns a line per attribute that has a known value in the
+// plan but a different value in the state. Values of sensitive attributes are not shown.
+func driftedAttributes(ctx context.Context, plan tfsdk.Plan, state tfsdk.State) ([]string, error) {
+	diffs, err := plan.Raw.Diff(state.Raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var mismatches []string
+	var reported []*tftypes.AttributePath
+
+	for _, d := range diffs {
+		// values computed during apply are unknown in the plan
+		if d.Value1 == nil || !d.Value1.IsKnown() {
+			continue
+		}
+
+		// a changed list is reported once, not once per element
+		covered := false
+		for _, parent := range reported {
+			steps := d.Path.Steps()
+			if len(steps) > len(parent.Steps()) && tftypes.NewAttributePathWithSteps(steps[:len(parent.Steps())]).Equal(parent) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+		reported = append(reported, d.Path)
+
+		name := attributePathString(d.Path)
+
+		attribute, err := state.Schema.AttributeAtTerraformPath(ctx, d.Path)
+		if err == nil && attribute.IsSensitive() {
+			mismatches = append(mismatches, "  - "+name+": (sensitive value)")
+			continue
+		}
+
+		persisted := "no value"
+		if d.Value2 != nil {
+			persisted = valueString(*d.Value2)
+		}
+		mismatches = append(mismatches, fmt.Sprintf("  - %s: planned %s, stored %s", name, valueString(*d.Value1), persisted))
+	}
+
+	// the diff follows the map order of objects
+	sort.Strings(mismatches)
+
+	return mismatches, nil
+}
+
+// valueString formats a value the way it is written in a configuration, aggregates are only described.
+func valueString(v tftypes.Value) string {
+	if v.IsNull() {
+		return "null"
+	}
+
+	switch {
+	case v.Type().Is(tftypes.String):
+		var s string
+		if err := v.As(&s); err == nil {
+			return strconv.Quote(s)
+		}
+	case v.Type().Is(tftypes.Number):
+		n := new(big.Float)
+		if err := v.As(&n); err == nil {
+			return n.Text('f', -1)
+		}
+	case v.Type().Is(tftypes.Bool):
+		var b bool
+		if err := v.As(&b); err == nil {
+			return strconv.FormatBool(b)
+		}
+	case v.Type().Is(tftypes.List{}), v.Type().Is(tftypes.Set{}), v.Type().Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		if err := v.As(&elements); err == nil {
+			return fmt.Sprintf("%d elements", len(elements))
+		}
+	}
+
+	return "a different value"
+}
+
+// attributePathString formats a path like provision.center.aws_size or pool_definition.attributes[0].
+func attributePathString(p *tftypes.AttributePath) string {
+	var b strings.Builder
+	for _, step := range p.Steps() {
+		switch s := step.(type) {
+		case tftypes.AttributeName:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(string(s))
+		case tftypes.ElementKeyInt:
+			fmt.Fprintf(&b, "[%d]", int64(s))
+		case tftypes.ElementKeyString:
+			fmt.Fprintf(&b, "[%q]", string(s))
+		default:
+			b.WriteString("[*]")
+		}
+	}
+	return b.String()
+}
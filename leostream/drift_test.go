@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDriftedAttributes(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewGatewayResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	gateway := gatewayResourceModel{
+		ID:               types.StringValue("5"),
+		Name:             types.StringValue("gateway"),
+		Address:          types.StringValue("gateway.example.com"),
+		Address_private:  types.StringValue(""),
+		Load_balancer_id: types.Int64Value(0),
+		Use_src_ip:       types.Int64Value(1),
+		Notes:            types.StringValue(""),
+	}
+
+	tests := map[string]struct {
+		plan   func(*gatewayResourceModel)
+		stored func(*gatewayResourceModel)
+		want   []string
+	}{
+		"no drift": {},
+		"unknown id in plan": {
+			plan: func(m *gatewayResourceModel) { m.ID = types.StringUnknown() },
+		},
+		"normalised values": {
+			stored: func(m *gatewayResourceModel) {
+				m.Address = types.StringValue("GATEWAY.example.com")
+				m.Use_src_ip = types.Int64Value(0)
+			},
+			want: []string{
+				`  - address: planned "gateway.example.com", stored "GATEWAY.example.com"`,
+				`  - use_src_ip: planned 1, stored 0`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			planned, stored := gateway, gateway
+			if test.plan != nil {
+				test.plan(&planned)
+			}
+			if test.stored != nil {
+				test.stored(&stored)
+			}
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			if diags := plan.Set(ctx, &planned); diags.HasError() {
+				t.Fatalf("plan: %v", diags)
+			}
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			if diags := state.Set(ctx, &stored); diags.HasError() {
+				t.Fatalf("state: %v", diags)
+			}
+
+			got, err := driftedAttributes(ctx, plan, state)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSetReadBackState(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewGatewayResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	planned := gatewayResourceModel{
+		ID:               types.StringUnknown(),
+		Name:             types.StringValue("gateway"),
+		Address:          types.StringUnknown(),
+		Address_private:  types.StringValue(""),
+		Load_balancer_id: types.Int64Value(0),
+		Use_src_ip:       types.Int64Value(1),
+		Notes:            types.StringValue("planned"),
+	}
+	stored := planned
+	stored.ID = types.StringValue("5")
+	stored.Address = types.StringValue("")
+	stored.Notes = types.StringValue("normalised")
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, &planned); diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	var diags diag.Diagnostics
+	setReadBackState(ctx, "gateway", &stored, plan, &state, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diags.WarningsCount() != 1 {
+		t.Errorf("got %d warnings, want 1 for the notes", diags.WarningsCount())
+	}
+
+	var got gatewayResourceModel
+	if diags := state.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("state: %v", diags)
+	}
+
+	// known planned values are kept, unknown values are taken from the broker
+	want := planned
+	want.ID = types.StringValue("5")
+	want.Address = types.StringValue("")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// apiError is an error response of the Leostream API.
+type apiError struct {
+	statusCode int
+	err        error
+}
+
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+func (e *apiError) Unwrap() error {
+	return e.err
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (e *apiError) StatusCode() int {
+	return e.statusCode
+}
+
+// newAPIError returns err as *apiError when it is an error response of the
+// Leostream API, other errors are returned as is. The Leostream client reports
+// error responses only as text, in the format "status: <code>, body: <body>".
+func newAPIError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	rest, ok := strings.CutPrefix(err.Error(), "status: ")
+	if !ok {
+		return err
+	}
+	code, _, ok := strings.Cut(rest, ", body: ")
+	if !ok {
+		return err
+	}
+	statusCode, convErr := strconv.Atoi(code)
+	if convErr != nil {
+		return err
+	}
+
+	return &apiError{statusCode: statusCode, err: err}
+}
+
+// isNotFound reports whether err is the not-found response of the Leostream API,
+// e.g. because the object was deleted outside Terraform.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode() == http.StatusNotFound
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := map[string]struct {
+		err error
+		// wantStatus is the status code of the *apiError, 0 when err is not an error response
+		wantStatus   int
+		wantNotFound bool
+	}{
+		"nil": {
+			err: nil,
+		},
+		"not found": {
+			err:          errors.New(`status: 404, body: {"error": "not found"}`),
+			wantStatus:   404,
+			wantNotFound: true,
+		},
+		"not found without body": {
+			err:          errors.New("status: 404, body: "),
+			wantStatus:   404,
+			wantNotFound: true,
+		},
+		"server error": {
+			err:        errors.New(`status: 500, body: {"error": "status: 404"}`),
+			wantStatus: 500,
+		},
+		"404 in the body only": {
+			err: errors.New(`unable to decode response: status: 404`),
+		},
+		"transport error": {
+			err: fmt.Errorf("Get \"https://broker/rest/v1/pools/1\": %w", errors.New("connection refused")),
+		},
+		"status without code": {
+			err: errors.New("status: unknown, body: "),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := newAPIError(test.err)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected the original error to be kept, got: %v", err)
+			}
+			var apiErr *apiError
+			if errors.As(err, &apiErr) {
+				if apiErr.StatusCode() != test.wantStatus {
+					t.Errorf("got status %d, want %d", apiErr.StatusCode(), test.wantStatus)
+				}
+				if apiErr.Error() != test.err.Error() {
+					t.Errorf("got message %q, want %q", apiErr.Error(), test.err.Error())
+				}
+			} else if test.wantStatus != 0 {
+				t.Errorf("expected an *apiError with status %d, got: %v", test.wantStatus, err)
+			}
+			if got := isNotFound(err); got != test.wantNotFound {
+				t.Errorf("got isNotFound %t, want %t", got, test.wantNotFound)
+			}
+		})
+	}
+}
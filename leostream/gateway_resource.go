@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,6 +16,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"gitlab.hocmodo.nl/community/leostream-client-go"
@@ -21,8 +25,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &gatewayResource{}
-	_ resource.ResourceWithConfigure = &gatewayResource{}
+	_ resource.Resource                 = &gatewayResource{}
+	_ resource.ResourceWithConfigure    = &gatewayResource{}
+	_ resource.ResourceWithImportState  = &gatewayResource{}
+	_ resource.ResourceWithUpgradeState = &gatewayResource{}
 )
 
 // NewGatewayResource is a helper function to simplify the provider implementation.
@@ -32,7 +38,7 @@ func NewGatewayResource() resource.Resource {
 
 // gatewayResource is the resource implementation.
 type gatewayResource struct {
-	client *leostream.Client
+	client apiClient
 }
 
 // gatewayResourceModel maps the resource schema data.
@@ -46,6 +52,34 @@ type gatewayResourceModel struct {
 	Notes            types.String `tfsdk:"notes"`
 }
 
+// common `Read` function for the resource, returns false when the object no longer exists
+func (o *gatewayResourceModel) Read(ctx context.Context, client apiClient, diags *diag.Diagnostics, id string) bool {
+	gateway, err := client.GetGateway(id)
+
+	if isNotFound(err) {
+		return false
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Error Reading Leostream Gateway",
+			"Could not read Leostream gateway ID "+id+": "+err.Error(),
+		)
+		return false
+	}
+
+	// Map response body to model
+	o.ID = types.StringValue(strconv.FormatInt(gateway.ID, 10))
+	o.Name = types.StringValue(gateway.Name)
+	o.Address = types.StringValue(gateway.Address)
+	o.Address_private = types.StringValue(gateway.Address_private)
+	o.Load_balancer_id = types.Int64Value(int64(gateway.Load_balancer_id))
+	o.Use_src_ip = types.Int64Value(int64(gateway.Use_src_ip))
+	o.Notes = types.StringValue(gateway.Notes)
+
+	return true
+}
+
 // Metadata returns the resource type name.
 func (r *gatewayResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_gateway"
@@ -54,6 +88,7 @@ func (r *gatewayResource) Metadata(_ context.Context, req resource.MetadataReque
 // Schema defines the schema for the resource.
 func (r *gatewayResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     schemaVersion(gatewayStateMigrations),
 		Description: `The gateway resource allows you to create, read, update, and delete gateways in Leostream.`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -66,20 +101,31 @@ func (r *gatewayResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			"name": schema.StringAttribute{
 				Description: "Display name of the gateway.",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"address": schema.StringAttribute{
 				Description: "Public IP address of the gateway.",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"address_private": schema.StringAttribute{
 				Description: "Private IP address of the gateway.",
 				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(CONFIG_GATEWAY_ADDRESS_PRIVATE),
 			},
 			"load_balancer_id": schema.Int64Attribute{
 				Description: "ID of the cluster associated with the gateway.",
 				Optional:    true,
 				Computed:    true,
-				Default:     int64default.StaticInt64(0),
+				Default:     int64default.StaticInt64(CONFIG_GATEWAY_LOAD_BALANCER_ID),
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
 			},
 			"use_src_ip": schema.Int64Attribute{
 				Description: `Method of source IP filtering
@@ -87,20 +133,26 @@ func (r *gatewayResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				1: use source IP filtering, but same port on gateway and desktop
 				2: use source IP filtering, but random port on gateway
 				`,
-				Optional: true,
-				Computed: true,
-				Default:  int64default.StaticInt64(0),
+				Optional:   true,
+				Computed:   true,
+				Default:    int64default.StaticInt64(CONFIG_GATEWAY_USE_SRC_IP),
+				Validators: []validator.Int64{int64validator.Between(CONFIG_GATEWAY_USE_SRC_IP_MIN, CONFIG_GATEWAY_USE_SRC_IP_MAX)},
 			},
 			"notes": schema.StringAttribute{
 				Description: "Notes for the gateway.",
 				Optional:    true,
 				Computed:    true,
-				Default:     stringdefault.StaticString(""),
+				Default:     stringdefault.StaticString(CONFIG_GATEWAY_NOTES),
 			},
 		},
 	}
 }
 
+// UpgradeState migrates state written by earlier schema versions.
+func (r *gatewayResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(gatewayStateMigrations)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *gatewayResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -118,7 +170,7 @@ func (r *gatewayResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	r.client = client
+	r.client = leostreamAPI{client: client}
 }
 
 // Create a new resource.
@@ -149,16 +201,17 @@ func (r *gatewayResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	// Map response body to schema and populate Computed attribute values
-	// convert int64 to string
-	plan.ID = types.StringValue(strconv.FormatInt(GwStored.Stored_data.ID, 10))
+	id := strconv.FormatInt(GwStored.Stored_data.ID, 10)
 
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
+	// Keep the ID of the new gateway in state first, so a failed read back leaves
+	// a tainted resource that Terraform replaces instead of an orphaned gateway
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Set state to the gateway as stored by the broker
+	r.readBack(ctx, id, req.Plan, &resp.State, &resp.Diagnostics)
 }
 
 // Read resource information.
@@ -172,27 +225,21 @@ func (r *gatewayResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// Get refreshed gateway value from Leostream
-	gateway, err := r.client.GetGateway(state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Leostream Gateway",
-			"Could not read Leostream gateway ID "+state.ID.ValueString()+": "+err.Error(),
-		)
+	var newState gatewayResourceModel
+	found := newState.Read(ctx, r.client, &resp.Diagnostics, state.ID.ValueString())
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Map response body to model
-
-	state.ID = types.StringValue(strconv.FormatInt(gateway.ID, 10))
-	state.Name = types.StringValue(gateway.Name)
-	state.Address = types.StringValue(gateway.Address)
-	state.Address_private = types.StringValue(gateway.Address_private)
-	state.Load_balancer_id = types.Int64Value(int64(gateway.Load_balancer_id))
-	state.Use_src_ip = types.Int64Value(int64(gateway.Use_src_ip))
-	state.Notes = types.StringValue(gateway.Notes)
+	// Remove the gateway from state when it was deleted outside Terraform, so it is planned for creation
+	if !found {
+		tflog.Warn(ctx, "Leostream gateway not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	// Set refreshed state
-	diags = resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -235,11 +282,28 @@ func (r *gatewayResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	// Set state to the gateway as stored by the broker
+	r.readBack(ctx, plan.ID.ValueString(), req.Plan, &resp.State, &resp.Diagnostics)
+}
+
+// readBack reads the gateway from the Leostream API after a create or update and
+// sets the state, warning about attributes that were not stored as planned.
+func (r *gatewayResource) readBack(ctx context.Context, id string, plan tfsdk.Plan, state *tfsdk.State, diags *diag.Diagnostics) {
+	var newState gatewayResourceModel
+	found := newState.Read(ctx, r.client, diags, id)
+	if diags.HasError() {
+		return
+	}
+
+	if !found {
+		diags.AddError(
+			"Unable to Read Back Gateway",
+			"Gateway "+id+" was not found in Leostream right after it was written.",
+		)
 		return
 	}
+
+	setReadBackState(ctx, "gateway", &newState, plan, state, diags)
 }
 
 func (r *gatewayResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -256,7 +320,7 @@ func (r *gatewayResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	// Delete existing gateway
 	err := r.client.DeleteGateway(state.ID.ValueString(), nil)
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting Leostream Gateway",
 			"Could not delete gateway, unexpected error: "+err.Error(),
@@ -266,6 +330,17 @@ func (r *gatewayResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *gatewayResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// import by numeric ID or by name
+	importStateByIdOrName(ctx, req, resp, "gateway", func() ([]importCandidate, error) {
+		gateways, err := r.client.GetGateways()
+		if err != nil {
+			return nil, err
+		}
+
+		var candidates []importCandidate
+		for _, gateway := range gateways {
+			candidates = append(candidates, importCandidate{ID: int64(gateway.ID), Name: gateway.Name})
+		}
+		return candidates, nil
+	})
 }
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccGatewayResource(t *testing.T) {
+	broker := newTestBroker(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccGatewayConfig(broker),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_gateway.test", "id", "1"),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "name", "gateway_us_east_1"),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "address", "gateway.example.com"),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "address_private", ""),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "load_balancer_id", "0"),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "use_src_ip", "0"),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "notes", ""),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "leostream_gateway.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import by name
+			{
+				ResourceName:      "leostream_gateway.test",
+				ImportState:       true,
+				ImportStateId:     "name:gateway_us_east_1",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: broker.providerConfig() + `
+resource "leostream_gateway" "test" {
+  name            = "gateway_us_east_1"
+  address         = "gateway.example.com"
+  address_private = "10.0.0.10"
+  use_src_ip      = 1
+  notes           = "updated"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_gateway.test", "id", "1"),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "address_private", "10.0.0.10"),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "use_src_ip", "1"),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "notes", "updated"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: broker.checkDestroyed("gateways"),
+	})
+}
+
+// Importing into an empty state needs a removed block, Terraform 1.7 or newer.
+func TestAccGatewayResourceImportPersist(t *testing.T) {
+	broker := newTestBroker(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGatewayConfig(broker),
+			},
+			// Forget the gateway and import it into an empty state
+			{
+				Config: testAccRemovedConfig(broker, "leostream_gateway.test"),
+			},
+			{
+				Config:             testAccGatewayConfig(broker),
+				ResourceName:       "leostream_gateway.test",
+				ImportState:        true,
+				ImportStateId:      "1",
+				ImportStatePersist: true,
+			},
+			// The imported state matches the configuration
+			{
+				Config: testAccGatewayConfig(broker),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+		CheckDestroy: broker.checkDestroyed("gateways"),
+	})
+}
+
+func testAccGatewayConfig(broker *testBroker) string {
+	return broker.providerConfig() + `
+resource "leostream_gateway" "test" {
+  name    = "gateway_us_east_1"
+  address = "gateway.example.com"
+}
+`
+}
+
+func TestAccGatewayResourceOmittedAttributes(t *testing.T) {
+	broker := newTestBroker(t)
+
+	// name and address are optional, the broker stores an empty string for them
+	config := broker.providerConfig() + `
+resource "leostream_gateway" "test" {
+  notes = "no name or address"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_gateway.test", "id", "1"),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "name", ""),
+					resource.TestCheckResourceAttr("leostream_gateway.test", "address", ""),
+				),
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config:            config,
+				ResourceName:      "leostream_gateway.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: broker.checkDestroyed("gateways"),
+	})
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGatewaysDataSource(t *testing.T) {
+	broker := newTestBroker(t)
+	broker.put("gateways", map[string]any{"name": "gateway_us_east_1", "address": "gateway.example.com"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: broker.providerConfig() + `
+data "leostream_gateways" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.leostream_gateways.test", "gateways.#", "1"),
+					resource.TestCheckResourceAttr("data.leostream_gateways.test", "gateways.0.id", "1"),
+					resource.TestCheckResourceAttr("data.leostream_gateways.test", "gateways.0.name", "gateway_us_east_1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// importCandidate is an object returned by one of the list APIs that can be imported by name.
+type importCandidate struct {
+	ID   int64
+	Name string
+}
+
+// importStateByIdOrName imports a resource by its numeric ID, or by its name when the
+// import identifier starts with CONFIG_IMPORT_NAME_PREFIX. The name is resolved with
+// the list function and must match exactly one object.
+func importStateByIdOrName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, kind string, list func() ([]importCandidate, error)) {
+	name, byName := importName(req.ID)
+	if !byName {
+		// retrieve import ID and save to id attribute
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	if name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			fmt.Sprintf("Expected an import identifier like %q or a numeric ID, got: %q", CONFIG_IMPORT_NAME_PREFIX+"my "+kind, req.ID),
+		)
+		return
+	}
+
+	candidates, err := list()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Import Leostream "+kind,
+			"Could not list "+kind+"s to resolve the name "+strconv.Quote(name)+": "+err.Error(),
+		)
+		return
+	}
+
+	var ids []string
+	for _, candidate := range candidates {
+		if candidate.Name == name {
+			ids = append(ids, strconv.FormatInt(candidate.ID, 10))
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Unable to Import Leostream "+kind,
+			"No "+kind+" found with the name "+strconv.Quote(name)+".",
+		)
+	case 1:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+	default:
+		resp.Diagnostics.AddError(
+			"Unable to Import Leostream "+kind,
+			"The name "+strconv.Quote(name)+" is ambiguous, it matches the "+kind+"s with IDs "+strings.Join(ids, ", ")+". "+
+				"Import the "+kind+" by its numeric ID instead.",
+		)
+	}
+}
+
+// importName returns the name of an import identifier that starts with CONFIG_IMPORT_NAME_PREFIX,
+// and false for any other identifier.
+func importName(id string) (string, bool) {
+	if !strings.HasPrefix(id, CONFIG_IMPORT_NAME_PREFIX) {
+		return "", false
+	}
+	return strings.TrimPrefix(id, CONFIG_IMPORT_NAME_PREFIX), true
+}
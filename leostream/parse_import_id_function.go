@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &parseImportIdFunction{}
+)
+
+// NewParseImportIdFunction is a helper function to simplify the provider implementation.
+func NewParseImportIdFunction() function.Function {
+	return &parseImportIdFunction{}
+}
+
+// parseImportIdFunction is the function implementation.
+type parseImportIdFunction struct{}
+
+// importIdModel maps the result of the parse_import_id function.
+type importIdModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (o importIdModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":   types.Int64Type,
+		"name": types.StringType,
+	}
+}
+
+// Metadata returns the function name.
+func (f *parseImportIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_import_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseImportIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse the import identifier of a Leostream resource",
+		Description: "Returns an object with the id and name of an import identifier. A numeric identifier sets id and leaves name null, " +
+			"an identifier like \"" + CONFIG_IMPORT_NAME_PREFIX + "AWS desktop pool 2\" sets name and leaves id null.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "import_id",
+				Description: "The numeric ID of the object, or its name prefixed with \"" + CONFIG_IMPORT_NAME_PREFIX + "\".",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: importIdModel{}.attrTypes(),
+		},
+	}
+}
+
+// Run returns the parsed import identifier.
+func (f *parseImportIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var importId string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &importId))
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := parseImportId(importId)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, err)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// parseImportId splits an import identifier into a numeric ID or a name, like importStateByIdOrName.
+func parseImportId(importId string) (importIdModel, *function.FuncError) {
+	result := importIdModel{
+		ID:   types.Int64Null(),
+		Name: types.StringNull(),
+	}
+
+	if name, byName := importName(importId); byName {
+		if name == "" {
+			return result, function.NewArgumentFuncError(0, "Missing name after "+strconv.Quote(CONFIG_IMPORT_NAME_PREFIX)+" in the import identifier.")
+		}
+		result.Name = types.StringValue(name)
+		return result, nil
+	}
+
+	id, err := strconv.ParseInt(importId, 10, 64)
+	if err != nil || id <= 0 {
+		return result, function.NewArgumentFuncError(0,
+			"Invalid import identifier "+strconv.Quote(importId)+", expected a numeric ID or a name like "+strconv.Quote(CONFIG_IMPORT_NAME_PREFIX+"my pool")+".")
+	}
+	result.ID = types.Int64Value(id)
+	return result, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestParseImportIdFunctionRun(t *testing.T) {
+	attrTypes := importIdModel{}.attrTypes()
+
+	tests := map[string]struct {
+		importId  string
+		want      map[string]attr.Value
+		wantError bool
+	}{
+		"numeric id": {
+			importId: "12",
+			want:     map[string]attr.Value{"id": types.Int64Value(12), "name": types.StringNull()},
+		},
+		"name": {
+			importId: CONFIG_IMPORT_NAME_PREFIX + "AWS desktop pool 2",
+			want:     map[string]attr.Value{"id": types.Int64Null(), "name": types.StringValue("AWS desktop pool 2")},
+		},
+		"numeric name": {
+			importId: CONFIG_IMPORT_NAME_PREFIX + "12",
+			want:     map[string]attr.Value{"id": types.Int64Null(), "name": types.StringValue("12")},
+		},
+		"empty name":   {importId: CONFIG_IMPORT_NAME_PREFIX, wantError: true},
+		"empty":        {importId: "", wantError: true},
+		"zero":         {importId: "0", wantError: true},
+		"not a number": {importId: "pool 2", wantError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := runFunction(&parseImportIdFunction{}, types.ObjectUnknown(attrTypes), types.StringValue(test.importId))
+
+			if test.wantError {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %v", resp.Error)
+			}
+
+			want := types.ObjectValueMust(attrTypes, test.want)
+			if got := resp.Result.Value(); !got.Equal(want) {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestAccParseImportIdFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "by_id" {
+  value = provider::leostream::parse_import_id("12")
+}
+
+output "by_name" {
+  value = provider::leostream::parse_import_id("name:AWS desktop pool 2")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("by_id", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"id":   knownvalue.Int64Exact(12),
+						"name": knownvalue.Null(),
+					})),
+					statecheck.ExpectKnownOutputValue("by_name", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"id":   knownvalue.Null(),
+						"name": knownvalue.StringExact("AWS desktop pool 2"),
+					})),
+				},
+			},
+			{
+				Config: `
+output "by_id" {
+  value = provider::leostream::parse_import_id("pool 2")
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid import identifier "pool 2"`),
+			},
+		},
+	})
+}
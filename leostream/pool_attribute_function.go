@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &poolAttributeFunction{}
+)
+
+// NewPoolAttributeFunction is a helper function to simplify the provider implementation.
+func NewPoolAttributeFunction() function.Function {
+	return &poolAttributeFunction{}
+}
+
+// poolAttributeFunction is the function implementation.
+type poolAttributeFunction struct{}
+
+// Metadata returns the function name.
+func (f *poolAttributeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "pool_attribute"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *poolAttributeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build an entry for the attributes of a pool definition",
+		Description: "Returns an object for the attributes list of the pool_definition of a pool, after checking the field, condition and value. " +
+			"The field is a column of the vm table, e.g. \"name\", or an LDAP attribute prefixed with \"" + CONFIG_POOL_ATTRIBUTE_AD_PREFIX + "\", " +
+			"or a column of the vm_gpu table prefixed with \"" + CONFIG_POOL_ATTRIBUTE_GPU_PREFIX + "\".",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "field",
+				Description: "The field to search, e.g. \"name\", \"" + CONFIG_POOL_ATTRIBUTE_AD_PREFIX + "department\" or \"" + CONFIG_POOL_ATTRIBUTE_GPU_PREFIX + "model\".",
+			},
+			function.StringParameter{
+				Name:        "condition",
+				Description: "The search conditional, one of " + strings.Join(CONFIG_POOL_CONDITION_TYPE_VALUES, ", ") + ".",
+			},
+			function.StringParameter{
+				Name:        "value",
+				Description: "The text to match. Must be an IP address or CIDR notation for the ip and np conditions.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: poolAttributesModel{}.attrTypes(),
+		},
+	}
+}
+
+// Run returns the pool attribute.
+func (f *poolAttributeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var field, condition, value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &field, &condition, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	attribute, err := poolAttribute(field, condition, value)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, err)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, attribute))
+}
+
+// poolAttribute checks the arguments of the pool_attribute function and returns the attribute.
+func poolAttribute(field, condition, value string) (poolAttributesModel, *function.FuncError) {
+	attribute := poolAttributesModel{
+		Vm_table_field:     types.StringValue(""),
+		Ad_attribute_field: types.StringValue(""),
+		Vm_gpu_field:       types.StringValue(""),
+		Text_to_match:      types.StringValue(value),
+		Condition_type:     types.StringValue(condition),
+	}
+
+	switch {
+	case strings.HasPrefix(field, CONFIG_POOL_ATTRIBUTE_AD_PREFIX):
+		attribute.Ad_attribute_field = types.StringValue(strings.TrimPrefix(field, CONFIG_POOL_ATTRIBUTE_AD_PREFIX))
+	case strings.HasPrefix(field, CONFIG_POOL_ATTRIBUTE_GPU_PREFIX):
+		attribute.Vm_gpu_field = types.StringValue(strings.TrimPrefix(field, CONFIG_POOL_ATTRIBUTE_GPU_PREFIX))
+	case field != "" && slices.Contains(CONFIG_POOL_VM_TABLE_FIELD_VALUES, field):
+		attribute.Vm_table_field = types.StringValue(field)
+	default:
+		return attribute, function.NewArgumentFuncError(0,
+			"Invalid field "+strconv.Quote(field)+", expected one of "+strings.Join(CONFIG_POOL_VM_TABLE_FIELD_VALUES[1:], ", ")+
+				", or a field name prefixed with "+strconv.Quote(CONFIG_POOL_ATTRIBUTE_AD_PREFIX)+" or "+strconv.Quote(CONFIG_POOL_ATTRIBUTE_GPU_PREFIX)+".")
+	}
+
+	if attribute.Ad_attribute_field.ValueString() == "" && attribute.Vm_gpu_field.ValueString() == "" && attribute.Vm_table_field.ValueString() == "" {
+		return attribute, function.NewArgumentFuncError(0, "Missing field name after "+strconv.Quote(field)+".")
+	}
+
+	if !slices.Contains(CONFIG_POOL_CONDITION_TYPE_VALUES, condition) {
+		return attribute, function.NewArgumentFuncError(1,
+			"Invalid condition "+strconv.Quote(condition)+", expected one of "+strings.Join(CONFIG_POOL_CONDITION_TYPE_VALUES, ", ")+".")
+	}
+
+	if slices.Contains(CONFIG_POOL_CONDITION_TYPE_CIDR_VALUES, condition) {
+		if _, _, err := net.ParseCIDR(value); err != nil && net.ParseIP(value) == nil {
+			return attribute, function.NewArgumentFuncError(2,
+				"Invalid value "+strconv.Quote(value)+" for condition "+strconv.Quote(condition)+", expected an IP address or CIDR notation, e.g. \"10.0.0.0/16\".")
+		}
+	}
+
+	return attribute, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// runFunction calls the function with the arguments and returns the response.
+func runFunction(f function.Function, result attr.Value, arguments ...attr.Value) function.RunResponse {
+	resp := function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, &resp)
+	return resp
+}
+
+func TestPoolAttributeFunctionRun(t *testing.T) {
+	attributeType := types.ObjectType{AttrTypes: poolAttributesModel{}.attrTypes()}
+
+	tests := map[string]struct {
+		field, condition, value string
+		want                    map[string]attr.Value
+		wantError               bool
+		wantArgument            int64
+	}{
+		"vm table field": {
+			field: "name", condition: "bw", value: "desktop-",
+			want: map[string]attr.Value{"vm_table_field": types.StringValue("name")},
+		},
+		"ad attribute field": {
+			field: "ad:department", condition: "eq", value: "sales",
+			want: map[string]attr.Value{"ad_attribute_field": types.StringValue("department")},
+		},
+		"vm gpu field": {
+			field: "gpu:model", condition: "ct", value: "A10",
+			want: map[string]attr.Value{"vm_gpu_field": types.StringValue("model")},
+		},
+		"cidr": {
+			field: "ip", condition: "ip", value: "10.0.0.0/16",
+			want: map[string]attr.Value{"vm_table_field": types.StringValue("ip")},
+		},
+		"ip address": {
+			field: "ip", condition: "np", value: "10.0.0.1",
+			want: map[string]attr.Value{"vm_table_field": types.StringValue("ip")},
+		},
+		"unknown field": {
+			field: "colour", condition: "eq", value: "red",
+			wantError: true, wantArgument: 0,
+		},
+		"empty field": {
+			field: "", condition: "eq", value: "red",
+			wantError: true, wantArgument: 0,
+		},
+		"missing prefixed field": {
+			field: "ad:", condition: "eq", value: "sales",
+			wantError: true, wantArgument: 0,
+		},
+		"unknown condition": {
+			field: "name", condition: "sw", value: "desktop-",
+			wantError: true, wantArgument: 1,
+		},
+		"invalid cidr": {
+			field: "ip", condition: "ip", value: "10.0.0.0/33",
+			wantError: true, wantArgument: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := runFunction(&poolAttributeFunction{}, types.ObjectUnknown(attributeType.AttrTypes),
+				types.StringValue(test.field), types.StringValue(test.condition), types.StringValue(test.value))
+
+			if test.wantError {
+				if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != test.wantArgument {
+					t.Fatalf("got error %v, want an error for argument %d", resp.Error, test.wantArgument)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %v", resp.Error)
+			}
+
+			want := types.ObjectValueMust(attributeType.AttrTypes, mergeAttributes(map[string]attr.Value{
+				"vm_table_field":     types.StringValue(""),
+				"ad_attribute_field": types.StringValue(""),
+				"vm_gpu_field":       types.StringValue(""),
+				"text_to_match":      types.StringValue(test.value),
+				"condition_type":     types.StringValue(test.condition),
+			}, test.want))
+			if got := resp.Result.Value(); !got.Equal(want) {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestAccPoolAttributeFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "attribute" {
+  value = provider::leostream::pool_attribute("ad:department", "eq", "sales")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("attribute", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"vm_table_field":     knownvalue.StringExact(""),
+						"ad_attribute_field": knownvalue.StringExact("department"),
+						"vm_gpu_field":       knownvalue.StringExact(""),
+						"text_to_match":      knownvalue.StringExact("sales"),
+						"condition_type":     knownvalue.StringExact("eq"),
+					})),
+				},
+			},
+			{
+				Config: `
+output "attribute" {
+  value = provider::leostream::pool_attribute("name", "sw", "desktop-")
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid condition "sw"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"gitlab.hocmodo.nl/community/leostream-client-go"
+)
+
+// awsPoolKind is the pool type for desktops provisioned in an Amazon center.
+var awsPoolKind = poolKind{
+	typeName:          "aws_pool",
+	label:             "AWS pool",
+	description:       `The AWS pool resource allows you to manage Leostream AWS pools. These pools are used to group desktops in AWS together for management and provisioning.`,
+	restrictByValues:  CONFIG_POOL_RESTRICT_BY_VALUES,
+	restrictByDefault: "C",
+	provisioner:       awsProvisioner{},
+}
+
+// NewAwsPoolResource is a helper function to simplify the provider implementation.
+func NewAwsPoolResource() resource.Resource {
+	return &poolResource{kind: awsPoolKind}
+}
+
+// awsProvisioner maps the center block of the provision block of an AWS pool.
+type awsProvisioner struct{}
+
+// schemaAttributes returns the center block
+func (p awsProvisioner) schemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"center": schema.SingleNestedAttribute{
+			Description: `Container for parameters related to Center. Offically:
+						Google (object)
+						or RHEV (object)
+						or Scale (object)
+						or OpenStack (object)
+						or (Amazon AWS (Provision from image (object)
+						or Provision from launch template (object)))
+						or Azure (object)
+						or vCenter (object)
+						or ProvisionCenter (null) (ProvisionCenter).
+						!This versoim of the provider only supports AWS.`,
+			Optional: false,
+			Required: true,
+			Attributes: map[string]schema.Attribute{
+				"id": schema.Int64Attribute{
+					Description: "Unique identifier for the center.",
+					Optional:    true,
+				},
+				"name": schema.StringAttribute{
+					Description: "Name of the center.",
+					Optional:    true,
+					Computed:    false,
+				},
+				"type": schema.StringAttribute{
+					Description: "Type of the center. Currently only AWS is supported: amazon",
+					Optional:    true,
+					Computed:    true,
+					Default:     stringdefault.StaticString(""),
+				},
+				"aws_size": schema.StringAttribute{
+					Description: `The size of the instance to provision.
+								eg. t2.micro`,
+					Optional: true,
+					Computed: true,
+					Default:  stringdefault.StaticString(""),
+				},
+				"provision_method": schema.StringAttribute{
+					Description: "The method of provisioning. Currently only 'image' is supported.",
+					Optional:    true,
+					Computed:    true,
+					Default:     stringdefault.StaticString("image"),
+					Validators:  []validator.String{stringvalidator.OneOf(CONFIG_POOL_PROVISION_METHOD_VALUES...)},
+				},
+				"aws_iam_name": schema.StringAttribute{
+					Description: "The name of the IAM role to use for the instance.",
+					Optional:    true,
+					Computed:    true,
+					Default:     stringdefault.StaticString(""),
+				},
+				"aws_sub_net": schema.StringAttribute{
+					Description: "The subnet ID to use for the instance.",
+					Optional:    true,
+					Computed:    true,
+					Default:     stringdefault.StaticString(""),
+					Validators:  []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(`^(subnet-[0-9a-f]+)?$`), "must be a subnet ID like subnet-0123456789abcdef0")},
+				},
+				"aws_sec_group": schema.StringAttribute{
+					Description: "The security group name to use for the instance.",
+					Optional:    true,
+					Computed:    true,
+					Default:     stringdefault.StaticString(""),
+				},
+				"aws_vpc_id": schema.StringAttribute{
+					Description: "The VPC ID to use for the instance.",
+					Optional:    true,
+					Computed:    true,
+					Default:     stringdefault.StaticString(""),
+					Validators:  []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(`^(vpc-[0-9a-f]+)?$`), "must be a VPC ID like vpc-0123456789abcdef0")},
+				},
+			},
+		},
+	}
+}
+
+// attrTypes - return attribute types of the center block
+func (p awsProvisioner) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"center": types.ObjectType{AttrTypes: awsCenterModel{}.attrTypes()},
+	}
+}
+
+// defaultObject - return default object of the center block
+func (p awsProvisioner) defaultObject() map[string]attr.Value {
+	return map[string]attr.Value{
+		"center": types.ObjectValueMust(awsCenterModel{}.attrTypes(), awsCenterModel{}.defaultObject()),
+	}
+}
+
+// toApi copies the center block of the plan into the provision config
+func (p awsProvisioner) toApi(ctx context.Context, attributes map[string]attr.Value, provisionConfig *leostream.Provision, diags *diag.Diagnostics) {
+	// Object for storing plan data for the center object in the provision object of the pool config
+	var centerConfig leostream.PoolAwsCenter
+
+	var planCenter awsCenterModel
+	if planCenterObject, ok := attributes["center"].(types.Object); ok && !planCenterObject.IsNull() {
+		diags.Append(planCenterObject.As(ctx, &planCenter, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	centerConfig.ID = int64(planCenter.ID.ValueInt64())
+	centerConfig.Name = planCenter.Name.ValueString()
+	centerConfig.Type = planCenter.Type.ValueString()
+	centerConfig.Provision_method = planCenter.Provision_method.ValueString()
+	centerConfig.Aws_size = planCenter.Aws_size.ValueString()
+	centerConfig.Aws_iam_name = planCenter.Aws_iam_name.ValueString()
+	centerConfig.Aws_sub_net = planCenter.Aws_sub_net.ValueString()
+	centerConfig.Aws_sec_group = planCenter.Aws_sec_group.ValueString()
+	centerConfig.Aws_vpc_id = planCenter.Aws_vpc_id.ValueString()
+
+	provisionConfig.Center = &centerConfig
+}
+
+// fromApi returns the center block of the provision config
+func (p awsProvisioner) fromApi(ctx context.Context, provisionConfig *leostream.Provision, diags *diag.Diagnostics) map[string]attr.Value {
+	// without a center in the provision config the default center is kept
+	if provisionConfig.Center == nil {
+		return p.defaultObject()
+	}
+
+	// Handle center attribute
+	var stateCenter awsCenterModel
+	stateCenter.ID = types.Int64Value(provisionConfig.Center.ID)
+	stateCenter.Name = types.StringValue(provisionConfig.Center.Name)
+	stateCenter.Type = types.StringValue(provisionConfig.Center.Type)
+	stateCenter.Provision_method = types.StringValue(provisionConfig.Center.Provision_method)
+	stateCenter.Aws_size = types.StringValue(provisionConfig.Center.Aws_size)
+	stateCenter.Aws_iam_name = types.StringValue(provisionConfig.Center.Aws_iam_name)
+	stateCenter.Aws_sub_net = types.StringValue(provisionConfig.Center.Aws_sub_net)
+	stateCenter.Aws_sec_group = types.StringValue(provisionConfig.Center.Aws_sec_group)
+	stateCenter.Aws_vpc_id = types.StringValue(provisionConfig.Center.Aws_vpc_id)
+
+	center, d := types.ObjectValueFrom(ctx, awsCenterModel{}.attrTypes(), &stateCenter)
+	diags.Append(d...)
+
+	return map[string]attr.Value{
+		"center": center,
+	}
+}
+
+// validateConfig rejects aws_* fields for centers that are not Amazon centers
+func (p awsProvisioner) validateConfig(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	validateAwsCenterFields(ctx, config, diags)
+}
+
+// centerModel maps center schema data
+type awsCenterModel struct {
+	ID               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Type             types.String `tfsdk:"type"`
+	Provision_method types.String `tfsdk:"provision_method"`
+	Aws_size         types.String `tfsdk:"aws_size"`
+	Aws_iam_name     types.String `tfsdk:"aws_iam_name"`
+	Aws_sub_net      types.String `tfsdk:"aws_sub_net"`
+	Aws_sec_group    types.String `tfsdk:"aws_sec_group"`
+	Aws_vpc_id       types.String `tfsdk:"aws_vpc_id"`
+}
+
+// attrTypes - return attribute types for this model
+func (o awsCenterModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":               types.Int64Type,
+		"name":             types.StringType,
+		"type":             types.StringType,
+		"provision_method": types.StringType,
+		"aws_size":         types.StringType,
+		"aws_iam_name":     types.StringType,
+		"aws_sub_net":      types.StringType,
+		"aws_sec_group":    types.StringType,
+		"aws_vpc_id":       types.StringType,
+	}
+}
+
+// defaultObject - return default object for this model
+func (o awsCenterModel) defaultObject() map[string]attr.Value {
+	return map[string]attr.Value{
+		"id":               types.Int64Value(0),
+		"name":             types.StringValue(""),
+		"type":             types.StringValue(""),
+		"provision_method": types.StringValue("image"),
+		"aws_size":         types.StringValue(""),
+		"aws_iam_name":     types.StringValue(""),
+		"aws_sub_net":      types.StringValue(""),
+		"aws_sec_group":    types.StringValue(""),
+		"aws_vpc_id":       types.StringValue(""),
+	}
+}
+
+// matches reports whether the pool has a provisioning center, which the provider
+// always sends for AWS pools
+func (p awsProvisioner) matches(provisionConfig *leostream.Provision) bool {
+	return provisionConfig != nil && provisionConfig.Center != nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAwsPoolResource(t *testing.T) {
+	broker := newTestBroker(t)
+	centerID := broker.put("centers", map[string]any{"center_definition": map[string]any{"name": "aws-center-us-east-1", "type": "amazon"}})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAwsPoolConfig(broker, centerID, "Desktops", "t3.medium"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "id", "1"),
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "name", "AWS desktop pool 1"),
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "pool_definition.restrict_by", "A"),
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "pool_definition.attributes.#", "1"),
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "provision.provision_on_off", "true"),
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "provision.provision_max", "5"),
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "provision.center.aws_size", "t3.medium"),
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "provision.center.provision_method", "image"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "leostream_aws_pool.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import by name
+			{
+				ResourceName:      "leostream_aws_pool.test",
+				ImportState:       true,
+				ImportStateId:     "name:AWS desktop pool 1",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAwsPoolConfig(broker, centerID, "Desktops (large)", "t3.large"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "id", "1"),
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "display_name", "Desktops (large)"),
+					resource.TestCheckResourceAttr("leostream_aws_pool.test", "provision.center.aws_size", "t3.large"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: broker.checkDestroyed("pools"),
+	})
+}
+
+// Importing into an empty state needs a removed block, Terraform 1.7 or newer.
+func TestAccAwsPoolResourceImportPersist(t *testing.T) {
+	broker := newTestBroker(t)
+	centerID := broker.put("centers", map[string]any{"center_definition": map[string]any{"name": "aws-center-us-east-1", "type": "amazon"}})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsPoolConfig(broker, centerID, "Desktops", "t3.medium"),
+			},
+			// Forget the pool and import it into an empty state
+			{
+				Config: testAccRemovedConfig(broker, "leostream_aws_pool.test"),
+			},
+			{
+				Config:             testAccAwsPoolConfig(broker, centerID, "Desktops", "t3.medium"),
+				ResourceName:       "leostream_aws_pool.test",
+				ImportState:        true,
+				ImportStateId:      "1",
+				ImportStatePersist: true,
+			},
+			// The imported state matches the configuration
+			{
+				Config: testAccAwsPoolConfig(broker, centerID, "Desktops", "t3.medium"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+		CheckDestroy: broker.checkDestroyed("pools"),
+	})
+}
+
+func testAccAwsPoolConfig(broker *testBroker, centerID int64, displayName string, size string) string {
+	return broker.providerConfig() + fmt.Sprintf(`
+resource "leostream_aws_pool" "test" {
+  name         = "AWS desktop pool 1"
+  display_name = %[1]q
+
+  pool_definition = {
+    restrict_by = "A"
+    attributes = [
+      {
+        vm_table_field = "name"
+        text_to_match  = "desktop-"
+        condition_type = "bw"
+      }
+    ]
+  }
+
+  provision = {
+    provision_on_off  = true
+    provision_max     = 5
+    provision_vm_name = "desktop-{SEQUENCE}"
+    mark_deletable    = true
+    center = {
+      id       = %[2]d
+      name     = "aws-center-us-east-1"
+      type     = "amazon"
+      aws_size = %[3]q
+    }
+  }
+}
+`, displayName, centerID, size)
+}
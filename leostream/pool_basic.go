@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gitlab.hocmodo.nl/community/leostream-client-go"
+)
+
+//todo
+// make center_name and center_type outputs of the center resource
+
+// basicPoolKind is the pool type for desktops that are not provisioned from a center.
+var basicPoolKind = poolKind{
+	typeName:          "basic_pool",
+	label:             "basic pool",
+	description:       `The basic pool resource allows you to manage Leostream pools. Basic pools are used to group desktops together for management and provisioning.`,
+	restrictByValues:  CONFIG_POOL_RESTRICT_BY_VALUES,
+	restrictByDefault: "A",
+	provisioner:       basicProvisioner{},
+}
+
+// NewBasicPoolResource is a helper function to simplify the provider implementation.
+func NewBasicPoolResource() resource.Resource {
+	return &poolResource{kind: basicPoolKind}
+}
+
+// basicProvisioner maps the provision attributes that only basic pools have.
+type basicProvisioner struct{}
+
+// schemaAttributes returns the basic pool specific provision attributes
+func (p basicProvisioner) schemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"provision_vm_name_next_value": schema.Int64Attribute{
+			Description: "The next value for sequential VM names",
+			Optional:    true,
+			Computed:    true,
+			Default:     int64default.StaticInt64(0),
+			Validators:  []validator.Int64{int64validator.AtLeast(0)},
+		},
+	}
+}
+
+// attrTypes - return attribute types of the basic pool specific provision attributes
+func (p basicProvisioner) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"provision_vm_name_next_value": types.Int64Type,
+	}
+}
+
+// defaultObject - return default values of the basic pool specific provision attributes
+func (p basicProvisioner) defaultObject() map[string]attr.Value {
+	return map[string]attr.Value{
+		"provision_vm_name_next_value": types.Int64Value(CONFIG_POOL_PROVISION_VM_NAME_NEXT_VALUE),
+	}
+}
+
+// toApi copies the basic pool specific provision attributes of the plan into the provision config
+func (p basicProvisioner) toApi(_ context.Context, attributes map[string]attr.Value, provisionConfig *leostream.Provision, _ *diag.Diagnostics) {
+	if nextValue, ok := attributes["provision_vm_name_next_value"].(types.Int64); ok {
+		provisionConfig.Provision_vm_name_next_value = nextValue.ValueInt64()
+	}
+}
+
+// fromApi returns the basic pool specific provision attributes of the provision config
+func (p basicProvisioner) fromApi(_ context.Context, provisionConfig *leostream.Provision, _ *diag.Diagnostics) map[string]attr.Value {
+	return map[string]attr.Value{
+		"provision_vm_name_next_value": types.Int64Value(provisionConfig.Provision_vm_name_next_value),
+	}
+}
+
+// validateConfig has nothing to validate for basic pools
+func (p basicProvisioner) validateConfig(_ context.Context, _ tfsdk.Config, _ *diag.Diagnostics) {
+}
+
+// matches reports whether the pool has no provisioning center
+func (p basicProvisioner) matches(provisionConfig *leostream.Provision) bool {
+	return provisionConfig == nil || provisionConfig.Center == nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBasicPoolResource(t *testing.T) {
+	broker := newTestBroker(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBasicPoolConfig(broker, "Desktops", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_basic_pool.test", "id", "1"),
+					resource.TestCheckResourceAttr("leostream_basic_pool.test", "pool_definition.restrict_by", "A"),
+					resource.TestCheckResourceAttr("leostream_basic_pool.test", "pool_definition.never_rogue", "true"),
+					resource.TestCheckResourceAttr("leostream_basic_pool.test", "provision.provision_on_off", "false"),
+					resource.TestCheckResourceAttr("leostream_basic_pool.test", "provision.provision_vm_name_next_value", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "leostream_basic_pool.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccBasicPoolConfig(broker, "Desktops (renamed)", 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("leostream_basic_pool.test", "id", "1"),
+					resource.TestCheckResourceAttr("leostream_basic_pool.test", "display_name", "Desktops (renamed)"),
+					resource.TestCheckResourceAttr("leostream_basic_pool.test", "provision.provision_vm_name_next_value", "10"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: broker.checkDestroyed("pools"),
+	})
+}
+
+// Importing into an empty state needs a removed block, Terraform 1.7 or newer.
+func TestAccBasicPoolResourceImportPersist(t *testing.T) {
+	broker := newTestBroker(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBasicPoolConfig(broker, "Desktops", 1),
+			},
+			// Forget the pool and import it into an empty state
+			{
+				Config: testAccRemovedConfig(broker, "leostream_basic_pool.test"),
+			},
+			{
+				Config:             testAccBasicPoolConfig(broker, "Desktops", 1),
+				ResourceName:       "leostream_basic_pool.test",
+				ImportState:        true,
+				ImportStateId:      "1",
+				ImportStatePersist: true,
+			},
+			// The imported state matches the configuration
+			{
+				Config: testAccBasicPoolConfig(broker, "Desktops", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+		CheckDestroy: broker.checkDestroyed("pools"),
+	})
+}
+
+func testAccBasicPoolConfig(broker *testBroker, displayName string, nextValue int) string {
+	return broker.providerConfig() + fmt.Sprintf(`
+resource "leostream_basic_pool" "test" {
+  name         = "Basic desktop pool 1"
+  display_name = %q
+
+  pool_definition = {
+    never_rogue = true
+    attributes = [
+      {
+        vm_table_field = "server_id"
+        text_to_match  = "51"
+        condition_type = "eq"
+      }
+    ]
+  }
+
+  provision = {
+    provision_vm_name            = "desktop-{SEQUENCE}"
+    provision_vm_name_next_value = %d
+  }
+}
+`, displayName, nextValue)
+}
+
+func TestAccBasicPoolResourceIntegerFlags(t *testing.T) {
+	broker := newTestBroker(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Flags used to be 0/1 integers, Terraform rejects them for the bool attributes
+			{
+				Config: broker.providerConfig() + `
+resource "leostream_basic_pool" "test" {
+  name = "Basic desktop pool 1"
+
+  pool_definition = {
+    never_rogue = 1
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`bool required`),
+			},
+		},
+	})
+}
@@ -0,0 +1,435 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"gitlab.hocmodo.nl/community/leostream-client-go"
+)
+
+// poolProvisioner maps the center specific attributes of the provision block of
+// a pool. Every pool resource type has its own provisioner, the rest of the pool
+// is mapped by the shared pool engine in this file. Adding a pool type for
+// another center type only requires a new provisioner and poolKind.
+type poolProvisioner interface {
+	// schemaAttributes returns the center specific attributes of the provision block
+	schemaAttributes() map[string]schema.Attribute
+	// attrTypes returns the attribute types of the center specific attributes
+	attrTypes() map[string]attr.Type
+	// defaultObject returns the defaults of the center specific attributes
+	defaultObject() map[string]attr.Value
+	// toApi copies the center specific attributes of the plan into the provision config
+	toApi(ctx context.Context, attributes map[string]attr.Value, provisionConfig *leostream.Provision, diags *diag.Diagnostics)
+	// fromApi returns the center specific attributes of the provision config for the state
+	fromApi(ctx context.Context, provisionConfig *leostream.Provision, diags *diag.Diagnostics) map[string]attr.Value
+	// validateConfig validates combinations of center specific attributes
+	validateConfig(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics)
+	// matches reports whether a pool with the provision config is of this pool type
+	matches(provisionConfig *leostream.Provision) bool
+}
+
+// poolKind describes a pool resource type.
+type poolKind struct {
+	// typeName is appended to the provider type name, e.g. aws_pool
+	typeName string
+	// label names the pool type in messages, e.g. "AWS pool"
+	label       string
+	description string
+	// restrictByValues are the accepted values of pool_definition.restrict_by,
+	// restrictByDefault is its default
+	restrictByValues  []string
+	restrictByDefault string
+	provisioner       poolProvisioner
+}
+
+// restrictByDescription - return the description of pool_definition.restrict_by with the values of this pool kind
+func (k poolKind) restrictByDescription() string {
+	description := "Restrict by:"
+	for _, value := range k.restrictByValues {
+		description += "\n" + value + " = " + CONFIG_POOL_RESTRICT_BY_LABELS[value]
+		if value == k.restrictByDefault {
+			description += " (default)"
+		}
+	}
+	return description
+}
+
+// provisionAttrTypes - return attribute types of the provision block, shared and center specific
+func (k poolKind) provisionAttrTypes() map[string]attr.Type {
+	return mergeAttributes(poolProvisionModel{}.attrTypes(), k.provisioner.attrTypes())
+}
+
+// provisionDefaultObject - return default object of the provision block, shared and center specific
+func (k poolKind) provisionDefaultObject() map[string]attr.Value {
+	return mergeAttributes(poolProvisionModel{}.defaultObject(), k.provisioner.defaultObject())
+}
+
+// poolResourceModel maps the resource schema data.
+type poolResourceModel struct {
+	ID                         types.String `tfsdk:"id"`
+	Name                       types.String `tfsdk:"name"`
+	Display_name               types.String `tfsdk:"display_name"`
+	Notes                      types.String `tfsdk:"notes"`
+	Running_desktops_threshold types.Int64  `tfsdk:"running_desktops_threshold"`
+	Pool_definition            types.Object `tfsdk:"pool_definition"`
+	Provision                  types.Object `tfsdk:"provision"`
+}
+
+// poolDefinitionModel maps filtering schema data
+type poolDefinitionModel struct {
+	Restrict_by         types.String `tfsdk:"restrict_by"`
+	Pool_attribute_join types.String `tfsdk:"pool_attribute_join"`
+	Server_ids          types.List   `tfsdk:"server_ids"`
+	Never_rogue         types.Bool   `tfsdk:"never_rogue"`
+	Use_vmotion         types.Bool   `tfsdk:"use_vmotion"`
+	Parent_pool_id      types.Int64  `tfsdk:"parent_pool_id"`
+	Attributes          types.List   `tfsdk:"attributes"`
+}
+
+// attrTypes - return attribute types for this model
+func (o poolDefinitionModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"restrict_by":         types.StringType,
+		"pool_attribute_join": types.StringType,
+		"server_ids":          types.ListType{ElemType: types.Int64Type},
+		"never_rogue":         types.BoolType,
+		"use_vmotion":         types.BoolType,
+		"parent_pool_id":      types.Int64Type,
+		"attributes":          types.ListType{ElemType: types.ObjectType{AttrTypes: poolAttributesModel{}.attrTypes()}},
+	}
+}
+
+// defaultObject - return default object for this model
+func (o poolDefinitionModel) defaultObject() map[string]attr.Value {
+	bootstrap_serverids := convertToAttrInt64(CONFIG_POOL_SERVERIDS)
+
+	return map[string]attr.Value{
+		"restrict_by":         types.StringValue(CONFIG_POOL_RESTRICT_BY),
+		"pool_attribute_join": types.StringValue(CONFIG_POOL_ATTRIBUTE_JOIN),
+		// Let the default value be an empty list
+		"server_ids":     types.ListValueMust(types.Int64Type, bootstrap_serverids),
+		"never_rogue":    types.BoolValue(false),
+		"use_vmotion":    types.BoolValue(false),
+		"parent_pool_id": types.Int64Value(0),
+		"attributes":     types.ListNull(types.ObjectType{AttrTypes: poolAttributesModel{}.attrTypes()}),
+	}
+}
+
+// poolProvisionModel maps the provision schema data shared by all pool types,
+// the center specific attributes are mapped by the poolProvisioner.
+type poolProvisionModel struct {
+	Provision_on_off          types.Bool   `tfsdk:"provision_on_off"`
+	Provision_max             types.Int64  `tfsdk:"provision_max"`
+	Provision_vm_id           types.Int64  `tfsdk:"provision_vm_id"`
+	Provision_server_id       types.Int64  `tfsdk:"provision_server_id"`
+	Provision_vm_name         types.String `tfsdk:"provision_vm_name"`
+	Provision_threshold       types.Int64  `tfsdk:"provision_threshold"`
+	Provision_tenant_id       types.Int64  `tfsdk:"provision_tenant_id"`
+	Provision_vm_display_name types.String `tfsdk:"provision_vm_display_name"`
+	Provision_url             types.String `tfsdk:"provision_url"`
+	Provision_limits_enforce  types.Bool   `tfsdk:"provision_limits_enforce"`
+	Mark_deletable            types.Bool   `tfsdk:"mark_deletable"`
+}
+
+// attrTypes - return attribute types for this model
+func (o poolProvisionModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"provision_on_off":          types.BoolType,
+		"provision_max":             types.Int64Type,
+		"provision_vm_id":           types.Int64Type,
+		"provision_server_id":       types.Int64Type,
+		"provision_vm_name":         types.StringType,
+		"provision_threshold":       types.Int64Type,
+		"provision_tenant_id":       types.Int64Type,
+		"provision_vm_display_name": types.StringType,
+		"provision_url":             types.StringType,
+		"provision_limits_enforce":  types.BoolType,
+		"mark_deletable":            types.BoolType,
+	}
+}
+
+// defaultObject - return default object for this model representing the provision object
+func (o poolProvisionModel) defaultObject() map[string]attr.Value {
+	return map[string]attr.Value{
+		"provision_on_off":          types.BoolValue(CONFIG_POOL_PROVISION_ON_OFF),
+		"provision_max":             types.Int64Value(CONFIG_POOL_PROVISION_MAX),
+		"provision_vm_id":           types.Int64Value(CONFIG_POOL_PROVISION_VM_ID),
+		"provision_server_id":       types.Int64Value(CONFIG_POOL_PROVISION_SERVER_ID),
+		"provision_vm_name":         types.StringValue(""),
+		"provision_threshold":       types.Int64Value(CONFIG_POOL_PROVISION_THRESHOLD),
+		"provision_tenant_id":       types.Int64Value(CONFIG_POOL_PROVISION_TENANT_ID),
+		"provision_vm_display_name": types.StringValue(""),
+		"provision_url":             types.StringValue(""),
+		"provision_limits_enforce":  types.BoolValue(CONFIG_POOL_PROVISION_LIMITS_ENFORCE),
+		"mark_deletable":            types.BoolValue(CONFIG_POOL_MARK_DELETABLE),
+	}
+}
+
+// poolAttributesModel maps pool definition attribute schema data
+type poolAttributesModel struct {
+	Vm_table_field     types.String `tfsdk:"vm_table_field"`
+	Ad_attribute_field types.String `tfsdk:"ad_attribute_field"`
+	Vm_gpu_field       types.String `tfsdk:"vm_gpu_field"`
+	Text_to_match      types.String `tfsdk:"text_to_match"`
+	Condition_type     types.String `tfsdk:"condition_type"`
+}
+
+// attrTypes - return attribute types for this model
+func (o poolAttributesModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"vm_table_field":     types.StringType,
+		"ad_attribute_field": types.StringType,
+		"vm_gpu_field":       types.StringType,
+		"text_to_match":      types.StringType,
+		"condition_type":     types.StringType,
+	}
+}
+
+// common `Read` function for all pool types, returns false when the object no longer exists
+func (o *poolResourceModel) Read(ctx context.Context, client apiClient, kind poolKind, diags *diag.Diagnostics, rtype string, id string) bool {
+	//Pool CONFIG
+	//get refreshed pool config value from Leostream API
+	poolConfig, err := client.GetPool(id)
+
+	if isNotFound(err) {
+		return false
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Unable to read Pool Configuration",
+			err.Error(),
+		)
+		return false
+	}
+
+	*o = poolStateFromConfig(ctx, poolConfig, kind, diags)
+
+	return !diags.HasError()
+}
+
+// poolStateFromConfig converts a pool returned by the Leostream API into the resource model.
+func poolStateFromConfig(ctx context.Context, poolConfig *leostream.Pool, kind poolKind, diags *diag.Diagnostics) poolResourceModel {
+	var o poolResourceModel
+
+	// The API omits empty nested objects
+	poolDefinitionConfig := poolConfig.Pool_definition
+	if poolDefinitionConfig == nil {
+		poolDefinitionConfig = &leostream.PoolDefinition{}
+	}
+	provisionConfig := poolConfig.Provision
+	if provisionConfig == nil {
+		provisionConfig = &leostream.Provision{}
+	}
+
+	// Map pool config to state
+	o.ID = types.StringValue(strconv.FormatInt(poolConfig.ID, 10))
+	o.Name = types.StringValue(poolConfig.Name)
+	o.Display_name = types.StringValue(poolConfig.Display_name)
+	o.Notes = types.StringValue(poolConfig.Notes)
+	o.Running_desktops_threshold = types.Int64Value(poolConfig.Running_desktops_threshold)
+
+	// Map pool definition to state
+	var statePoolDefinition poolDefinitionModel
+	statePoolDefinition.Restrict_by = types.StringValue(poolDefinitionConfig.Restrict_by)
+	statePoolDefinition.Pool_attribute_join = types.StringValue(poolDefinitionConfig.Pool_attribute_join)
+	// An empty list is stored when server_ids is not configured, normalise it to the schema default
+	if len(poolDefinitionConfig.Server_ids) == 0 {
+		statePoolDefinition.Server_ids = types.ListNull(types.Int64Type)
+	} else {
+		var d diag.Diagnostics
+		statePoolDefinition.Server_ids, d = types.ListValueFrom(ctx, types.Int64Type, poolDefinitionConfig.Server_ids)
+		diags.Append(d...)
+	}
+	statePoolDefinition.Never_rogue = types.BoolValue(poolDefinitionConfig.Never_rogue != 0)
+	statePoolDefinition.Use_vmotion = types.BoolValue(poolDefinitionConfig.Use_vmotion != 0)
+	statePoolDefinition.Parent_pool_id = types.Int64Value(poolDefinitionConfig.Parent_pool_id)
+
+	// Create a slice of attributesModel called statePoolDefinitionAttributes
+	var statePoolDefinitionAttributes []poolAttributesModel
+	// Loop through the poolDefinitionConfig.Attributes and assign the values to the stateAttributes
+	for _, attribute := range poolDefinitionConfig.Attributes {
+		var stateAttributes poolAttributesModel
+		stateAttributes.Vm_table_field = types.StringValue(attribute.Vm_table_field)
+		stateAttributes.Ad_attribute_field = types.StringValue(attribute.Ad_attribute_field)
+		stateAttributes.Vm_gpu_field = types.StringValue(attribute.Vm_gpu_field)
+		stateAttributes.Text_to_match = types.StringValue(attribute.Text_to_match)
+		stateAttributes.Condition_type = types.StringValue(attribute.Condition_type)
+		// Append the stateAttributes to the statePoolDefinitionAttributes
+		statePoolDefinitionAttributes = append(statePoolDefinitionAttributes, stateAttributes)
+	}
+
+	// convert to a list, no attributes is the schema default null
+	if len(statePoolDefinitionAttributes) == 0 {
+		statePoolDefinition.Attributes = types.ListNull(types.ObjectType{AttrTypes: poolAttributesModel{}.attrTypes()})
+	} else {
+		var d diag.Diagnostics
+		statePoolDefinition.Attributes, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: poolAttributesModel{}.attrTypes()}, statePoolDefinitionAttributes)
+		diags.Append(d...)
+	}
+
+	//Add pool definition to pool model
+	var d diag.Diagnostics
+	o.Pool_definition, d = types.ObjectValueFrom(ctx, poolDefinitionModel{}.attrTypes(), &statePoolDefinition)
+	diags.Append(d...)
+	if diags.HasError() {
+		return o
+	}
+
+	// Handle provision attribute
+	var stateProvision poolProvisionModel
+	stateProvision.Provision_on_off = types.BoolValue(provisionConfig.Provision_on_off != 0)
+	stateProvision.Provision_max = types.Int64Value(provisionConfig.Provision_max)
+	stateProvision.Provision_vm_id = types.Int64Value(provisionConfig.Provision_vm_id)
+	stateProvision.Provision_server_id = types.Int64Value(provisionConfig.Provision_server_id)
+	stateProvision.Provision_vm_name = types.StringValue(provisionConfig.Provision_vm_name)
+	stateProvision.Provision_threshold = types.Int64Value(provisionConfig.Provision_threshold)
+	stateProvision.Provision_tenant_id = types.Int64Value(provisionConfig.Provision_tenant_id)
+	stateProvision.Provision_vm_display_name = types.StringValue(provisionConfig.Provision_vm_display_name)
+	stateProvision.Provision_url = types.StringValue(provisionConfig.Provision_url)
+	stateProvision.Provision_limits_enforce = types.BoolValue(provisionConfig.Provision_limits_enforce != 0)
+	stateProvision.Mark_deletable = types.BoolValue(provisionConfig.Mark_deletable != 0)
+
+	// Add provision to pool model, together with the center specific attributes
+	provision, d := types.ObjectValueFrom(ctx, poolProvisionModel{}.attrTypes(), &stateProvision)
+	diags.Append(d...)
+	if diags.HasError() {
+		return o
+	}
+
+	o.Provision, d = types.ObjectValue(
+		kind.provisionAttrTypes(),
+		mergeAttributes(provision.Attributes(), kind.provisioner.fromApi(ctx, provisionConfig, diags)),
+	)
+	diags.Append(d...)
+
+	return o
+}
+
+// poolConfigFromPlan converts the plan into the pool config sent to the Leostream API.
+// It returns nil when the plan could not be converted.
+func poolConfigFromPlan(ctx context.Context, plan *poolResourceModel, kind poolKind, diags *diag.Diagnostics) *leostream.Pool {
+	// Instantiate empty object for storing plan data
+	var poolConfig leostream.Pool
+
+	// Populate pool config from plan
+	poolConfig.Name = plan.Name.ValueString()
+	poolConfig.Display_name = plan.Display_name.ValueString()
+	poolConfig.Notes = plan.Notes.ValueString()
+	poolConfig.Running_desktops_threshold = plan.Running_desktops_threshold.ValueInt64()
+
+	// Unpack nested attributes from plan for the pool definition
+	var planPoolDefinition poolDefinitionModel
+	diags.Append(plan.Pool_definition.As(ctx, &planPoolDefinition, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	// Instantiate empty object for storing plan data for the pool definition object in the pool config
+	var poolDefinitionConfig leostream.PoolDefinition
+
+	// Populate pool definition config from plan
+	poolDefinitionConfig.Restrict_by = planPoolDefinition.Restrict_by.ValueString()
+	poolDefinitionConfig.Pool_attribute_join = planPoolDefinition.Pool_attribute_join.ValueString()
+	if len(planPoolDefinition.Server_ids.Elements()) > 0 {
+		diags.Append(planPoolDefinition.Server_ids.ElementsAs(ctx, &poolDefinitionConfig.Server_ids, false)...)
+		if diags.HasError() {
+			return nil
+		}
+	}
+	poolDefinitionConfig.Never_rogue = boolToInt64(planPoolDefinition.Never_rogue.ValueBool())
+	poolDefinitionConfig.Use_vmotion = boolToInt64(planPoolDefinition.Use_vmotion.ValueBool())
+	poolDefinitionConfig.Parent_pool_id = planPoolDefinition.Parent_pool_id.ValueInt64()
+
+	// Populate pool_definition Attributes field from plan (but only if it exists), an
+	// omitted list is unknown until the broker returned it
+	var planAttributes []poolAttributesModel
+	if !planPoolDefinition.Attributes.IsNull() && !planPoolDefinition.Attributes.IsUnknown() {
+		diags.Append(planPoolDefinition.Attributes.ElementsAs(ctx, &planAttributes, false)...)
+		if diags.HasError() {
+			return nil
+		}
+	}
+
+	// Loop through the planAttributes and assign the values to the attributes list of the pool definition
+	for _, attribute := range planAttributes {
+		var attributeConfig leostream.PoolAttributes
+		attributeConfig.Vm_table_field = attribute.Vm_table_field.ValueString()
+		attributeConfig.Ad_attribute_field = attribute.Ad_attribute_field.ValueString()
+		attributeConfig.Vm_gpu_field = attribute.Vm_gpu_field.ValueString()
+		attributeConfig.Text_to_match = attribute.Text_to_match.ValueString()
+		attributeConfig.Condition_type = attribute.Condition_type.ValueString()
+
+		poolDefinitionConfig.Attributes = append(poolDefinitionConfig.Attributes, attributeConfig)
+	}
+
+	// Assign the pool definition config to the pool config
+	poolConfig.Pool_definition = &poolDefinitionConfig
+
+	// Unpack the shared attributes of the provision object, the rest is up to the provisioner
+	var planProvision poolProvisionModel
+	diags.Append(objectSubset(poolProvisionModel{}.attrTypes(), plan.Provision.Attributes(), diags).As(ctx, &planProvision, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	// Object for storing plan data for the provision object in the pool config
+	var provisionConfig leostream.Provision
+
+	// Populate pool provision config from plan
+	provisionConfig.Provision_on_off = boolToInt64(planProvision.Provision_on_off.ValueBool())
+	provisionConfig.Provision_max = planProvision.Provision_max.ValueInt64()
+	provisionConfig.Provision_vm_id = planProvision.Provision_vm_id.ValueInt64()
+	provisionConfig.Provision_server_id = planProvision.Provision_server_id.ValueInt64()
+	provisionConfig.Provision_vm_name = planProvision.Provision_vm_name.ValueString()
+	provisionConfig.Provision_threshold = planProvision.Provision_threshold.ValueInt64()
+	provisionConfig.Provision_tenant_id = planProvision.Provision_tenant_id.ValueInt64()
+	provisionConfig.Provision_vm_display_name = planProvision.Provision_vm_display_name.ValueString()
+	provisionConfig.Provision_url = planProvision.Provision_url.ValueString()
+	provisionConfig.Provision_limits_enforce = boolToInt64(planProvision.Provision_limits_enforce.ValueBool())
+	provisionConfig.Mark_deletable = boolToInt64(planProvision.Mark_deletable.ValueBool())
+
+	kind.provisioner.toApi(ctx, plan.Provision.Attributes(), &provisionConfig, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	poolConfig.Provision = &provisionConfig
+
+	return &poolConfig
+}
+
+// objectSubset returns an object of the given attribute types, taking the values
+// from the attributes of a larger object.
+func objectSubset(attrTypes map[string]attr.Type, attributes map[string]attr.Value, diags *diag.Diagnostics) types.Object {
+	values := make(map[string]attr.Value, len(attrTypes))
+	for name := range attrTypes {
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		}
+	}
+
+	object, d := types.ObjectValue(attrTypes, values)
+	diags.Append(d...)
+
+	return object
+}
+
+// mergeAttributes returns the union of the given attribute maps.
+func mergeAttributes[T any](maps ...map[string]T) map[string]T {
+	merged := map[string]T{}
+	for _, m := range maps {
+		for name, value := range m {
+			merged[name] = value
+		}
+	}
+	return merged
+}
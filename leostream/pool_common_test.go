@@ -0,0 +1,419 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gitlab.hocmodo.nl/community/leostream-client-go"
+)
+
+var testPoolAttributeType = types.ObjectType{AttrTypes: poolAttributesModel{}.attrTypes()}
+
+// testPoolPlan returns the plan of a pool with the schema defaults, with the
+// given attributes of the pool definition and provision objects replaced.
+func testPoolPlan(kind poolKind, definition map[string]attr.Value, provision map[string]attr.Value) poolResourceModel {
+	return poolResourceModel{
+		ID:                         types.StringUnknown(),
+		Name:                       types.StringValue("pool"),
+		Display_name:               types.StringValue("Pool"),
+		Notes:                      types.StringValue(""),
+		Running_desktops_threshold: types.Int64Value(0),
+		Pool_definition: types.ObjectValueMust(
+			poolDefinitionModel{}.attrTypes(),
+			mergeAttributes(poolDefinitionModel{}.defaultObject(), definition),
+		),
+		Provision: types.ObjectValueMust(
+			kind.provisionAttrTypes(),
+			mergeAttributes(kind.provisionDefaultObject(), provision),
+		),
+	}
+}
+
+// testPoolAttribute returns a pool attribute matching the server ID.
+func testPoolAttribute(serverID string) attr.Value {
+	return types.ObjectValueMust(poolAttributesModel{}.attrTypes(), map[string]attr.Value{
+		"vm_table_field":     types.StringValue("server_id"),
+		"ad_attribute_field": types.StringValue(""),
+		"vm_gpu_field":       types.StringValue(""),
+		"text_to_match":      types.StringValue(serverID),
+		"condition_type":     types.StringValue("eq"),
+	})
+}
+
+// testPoolConfig returns the pool config of a pool with the schema defaults.
+func testPoolConfig(kind poolKind) leostream.Pool {
+	pool := leostream.Pool{
+		Name:         "pool",
+		Display_name: "Pool",
+		Pool_definition: &leostream.PoolDefinition{
+			Restrict_by:         CONFIG_POOL_RESTRICT_BY,
+			Pool_attribute_join: CONFIG_POOL_ATTRIBUTE_JOIN,
+			Server_ids:          CONFIG_POOL_SERVERIDS,
+		},
+		Provision: &leostream.Provision{},
+	}
+	if kind.provisioner == (awsProvisioner{}) {
+		pool.Provision.Center = &leostream.PoolAwsCenter{Provision_method: "image"}
+	}
+	return pool
+}
+
+func TestPoolConfigFromPlan(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		kind       poolKind
+		definition map[string]attr.Value
+		provision  map[string]attr.Value
+		plan       func(*poolResourceModel)
+		want       func(*leostream.Pool)
+	}{
+		// the default server_ids are CONFIG_POOL_SERVERIDS
+		"aws defaults": {
+			kind: awsPoolKind,
+		},
+		"basic defaults": {
+			kind: basicPoolKind,
+		},
+		"configured server_ids": {
+			kind: awsPoolKind,
+			definition: map[string]attr.Value{
+				"server_ids": types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(3), types.Int64Value(5)}),
+			},
+			want: func(p *leostream.Pool) { p.Pool_definition.Server_ids = []int64{3, 5} },
+		},
+		"null server_ids": {
+			kind:       awsPoolKind,
+			definition: map[string]attr.Value{"server_ids": types.ListNull(types.Int64Type)},
+			want:       func(p *leostream.Pool) { p.Pool_definition.Server_ids = nil },
+		},
+		"unknown server_ids": {
+			kind:       awsPoolKind,
+			definition: map[string]attr.Value{"server_ids": types.ListUnknown(types.Int64Type)},
+			want:       func(p *leostream.Pool) { p.Pool_definition.Server_ids = nil },
+		},
+		"empty server_ids": {
+			kind:       awsPoolKind,
+			definition: map[string]attr.Value{"server_ids": types.ListValueMust(types.Int64Type, []attr.Value{})},
+			want:       func(p *leostream.Pool) { p.Pool_definition.Server_ids = nil },
+		},
+		"empty attributes": {
+			kind:       basicPoolKind,
+			definition: map[string]attr.Value{"attributes": types.ListValueMust(testPoolAttributeType, []attr.Value{})},
+		},
+		"attributes": {
+			kind: basicPoolKind,
+			definition: map[string]attr.Value{
+				"restrict_by": types.StringValue("A"),
+				"attributes":  types.ListValueMust(testPoolAttributeType, []attr.Value{testPoolAttribute("51"), testPoolAttribute("52")}),
+			},
+			want: func(p *leostream.Pool) {
+				p.Pool_definition.Restrict_by = "A"
+				p.Pool_definition.Attributes = []leostream.PoolAttributes{
+					{Vm_table_field: "server_id", Text_to_match: "51", Condition_type: "eq"},
+					{Vm_table_field: "server_id", Text_to_match: "52", Condition_type: "eq"},
+				}
+			},
+		},
+		"flags": {
+			kind:       basicPoolKind,
+			definition: map[string]attr.Value{"never_rogue": types.BoolValue(true), "use_vmotion": types.BoolValue(false)},
+			provision:  map[string]attr.Value{"provision_on_off": types.BoolValue(true), "mark_deletable": types.BoolValue(true)},
+			want: func(p *leostream.Pool) {
+				p.Pool_definition.Never_rogue = 1
+				p.Provision.Provision_on_off = 1
+				p.Provision.Mark_deletable = 1
+			},
+		},
+		"null and unknown values": {
+			kind: basicPoolKind,
+			plan: func(m *poolResourceModel) {
+				m.Display_name = types.StringNull()
+				m.Running_desktops_threshold = types.Int64Unknown()
+			},
+			provision: map[string]attr.Value{"provision_max": types.Int64Unknown(), "provision_vm_name": types.StringNull()},
+			want:      func(p *leostream.Pool) { p.Display_name = "" },
+		},
+		"aws center": {
+			kind: awsPoolKind,
+			provision: map[string]attr.Value{
+				"center": types.ObjectValueMust(awsCenterModel{}.attrTypes(), mergeAttributes(awsCenterModel{}.defaultObject(), map[string]attr.Value{
+					"id":       types.Int64Value(51),
+					"name":     types.StringValue("aws-center-us-east-1"),
+					"type":     types.StringValue(CONFIG_CENTER_TYPE_AMAZON),
+					"aws_size": types.StringValue("t3.medium"),
+				})),
+			},
+			want: func(p *leostream.Pool) {
+				p.Provision.Center.ID = 51
+				p.Provision.Center.Name = "aws-center-us-east-1"
+				p.Provision.Center.Type = CONFIG_CENTER_TYPE_AMAZON
+				p.Provision.Center.Aws_size = "t3.medium"
+			},
+		},
+		"basic next value": {
+			kind:      basicPoolKind,
+			provision: map[string]attr.Value{"provision_vm_name_next_value": types.Int64Value(8)},
+			want:      func(p *leostream.Pool) { p.Provision.Provision_vm_name_next_value = 8 },
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plan := testPoolPlan(test.kind, test.definition, test.provision)
+			if test.plan != nil {
+				test.plan(&plan)
+			}
+
+			want := testPoolConfig(test.kind)
+			if test.want != nil {
+				test.want(&want)
+			}
+
+			var diags diag.Diagnostics
+			got := poolConfigFromPlan(ctx, &plan, test.kind, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !reflect.DeepEqual(got, &want) {
+				t.Errorf("got\n%+v %+v %+v\nwant\n%+v %+v %+v", *got, *got.Pool_definition, *got.Provision, want, *want.Pool_definition, *want.Provision)
+			}
+		})
+	}
+}
+
+func TestPoolConfigFromPlanInvalid(t *testing.T) {
+	plan := testPoolPlan(basicPoolKind, nil, nil)
+	plan.Pool_definition = types.ObjectUnknown(poolDefinitionModel{}.attrTypes())
+
+	var diags diag.Diagnostics
+	if got := poolConfigFromPlan(context.Background(), &plan, basicPoolKind, &diags); got != nil || !diags.HasError() {
+		t.Errorf("got %+v and %v, want an error", got, diags)
+	}
+}
+
+func TestPoolStateFromConfig(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		kind   poolKind
+		config func(*leostream.Pool)
+		want   func(*poolResourceModel)
+	}{
+		"aws defaults": {
+			kind: awsPoolKind,
+		},
+		"basic defaults": {
+			kind: basicPoolKind,
+		},
+		"empty server_ids": {
+			kind:   awsPoolKind,
+			config: func(p *leostream.Pool) { p.Pool_definition.Server_ids = []int64{} },
+			want: func(m *poolResourceModel) {
+				m.Pool_definition = testPoolObjectWith(m.Pool_definition, "server_ids", types.ListNull(types.Int64Type))
+			},
+		},
+		"server_ids": {
+			kind:   awsPoolKind,
+			config: func(p *leostream.Pool) { p.Pool_definition.Server_ids = []int64{3, 5} },
+			want: func(m *poolResourceModel) {
+				m.Pool_definition = testPoolObjectWith(m.Pool_definition, "server_ids",
+					types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(3), types.Int64Value(5)}))
+			},
+		},
+		"attributes": {
+			kind: basicPoolKind,
+			config: func(p *leostream.Pool) {
+				p.Pool_definition.Attributes = []leostream.PoolAttributes{{Vm_table_field: "server_id", Text_to_match: "51", Condition_type: "eq"}}
+			},
+			want: func(m *poolResourceModel) {
+				m.Pool_definition = testPoolObjectWith(m.Pool_definition, "attributes",
+					types.ListValueMust(testPoolAttributeType, []attr.Value{testPoolAttribute("51")}))
+			},
+		},
+		"empty attributes": {
+			kind:   basicPoolKind,
+			config: func(p *leostream.Pool) { p.Pool_definition.Attributes = []leostream.PoolAttributes{} },
+		},
+		"flags": {
+			kind: basicPoolKind,
+			config: func(p *leostream.Pool) {
+				p.Pool_definition.Use_vmotion = 1
+				p.Provision.Provision_limits_enforce = 1
+			},
+			want: func(m *poolResourceModel) {
+				m.Pool_definition = testPoolObjectWith(m.Pool_definition, "use_vmotion", types.BoolValue(true))
+				m.Provision = testPoolObjectWith(m.Provision, "provision_limits_enforce", types.BoolValue(true))
+			},
+		},
+		"aws center": {
+			kind:   awsPoolKind,
+			config: func(p *leostream.Pool) { p.Provision.Center.Aws_size = "t3.large" },
+			want: func(m *poolResourceModel) {
+				center := m.Provision.Attributes()["center"].(types.Object)
+				m.Provision = testPoolObjectWith(m.Provision, "center", testPoolObjectWith(center, "aws_size", types.StringValue("t3.large")))
+			},
+		},
+		"basic next value": {
+			kind:   basicPoolKind,
+			config: func(p *leostream.Pool) { p.Provision.Provision_vm_name_next_value = 8 },
+			want: func(m *poolResourceModel) {
+				m.Provision = testPoolObjectWith(m.Provision, "provision_vm_name_next_value", types.Int64Value(8))
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testPoolConfig(test.kind)
+			config.ID = 7
+			if test.config != nil {
+				test.config(&config)
+			}
+
+			// the state of the defaults is the plan of the defaults
+			want := testPoolPlan(test.kind, nil, nil)
+			want.ID = types.StringValue("7")
+			if test.want != nil {
+				test.want(&want)
+			}
+
+			var diags diag.Diagnostics
+			got := poolStateFromConfig(ctx, &config, test.kind, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got\n%+v\nwant\n%+v", got, want)
+			}
+		})
+	}
+}
+
+func TestPoolStateFromConfigMissingObjects(t *testing.T) {
+	for name, kind := range map[string]poolKind{"aws": awsPoolKind, "basic": basicPoolKind} {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := poolStateFromConfig(context.Background(), &leostream.Pool{ID: 7}, kind, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got.Pool_definition.IsNull() || got.Provision.IsNull() {
+				t.Errorf("got %+v, want empty objects", got)
+			}
+		})
+	}
+}
+
+// testPoolObjectWith returns the object with one attribute replaced.
+func testPoolObjectWith(object types.Object, name string, value attr.Value) types.Object {
+	return types.ObjectValueMust(object.AttributeTypes(context.Background()), mergeAttributes(object.Attributes(), map[string]attr.Value{name: value}))
+}
+
+func TestPoolKindRestrictBy(t *testing.T) {
+	for name, kind := range map[string]poolKind{"aws": awsPoolKind, "basic": basicPoolKind} {
+		t.Run(name, func(t *testing.T) {
+			if !slices.Contains(kind.restrictByValues, kind.restrictByDefault) {
+				t.Errorf("default %q is not one of %v", kind.restrictByDefault, kind.restrictByValues)
+			}
+			for _, value := range kind.restrictByValues {
+				if _, ok := CONFIG_POOL_RESTRICT_BY_LABELS[value]; !ok {
+					t.Errorf("no description for %q", value)
+				}
+			}
+		})
+	}
+
+	// Both pool kinds accept every restrict_by value, only the default differs
+	if got, want := len(basicPoolKind.restrictByValues), len(CONFIG_POOL_RESTRICT_BY_VALUES); got != want {
+		t.Errorf("basic pools accept %d restrict_by values, want %d", got, want)
+	}
+	if got := basicPoolKind.restrictByDescription(); !strings.Contains(got, "\nA = by attribute (default)\n") {
+		t.Errorf("basic pool description does not mark A as default: %q", got)
+	}
+	if got := awsPoolKind.restrictByDescription(); !strings.Contains(got, "\nC = by centers (default)\n") {
+		t.Errorf("AWS pool description does not mark C as default: %q", got)
+	}
+}
+
+func TestPoolImportByNameMatchesKind(t *testing.T) {
+	ctx := context.Background()
+
+	client := newFakeClient()
+	aws := testPool()
+	aws.Name = "Desktops"
+	basic := leostream.Pool{Name: "Desktops", Provision: &leostream.Provision{}}
+	client.pools["1"] = aws
+	client.pools["2"] = basic
+	client.pools["3"] = leostream.Pool{Name: "Basic only"}
+
+	tests := map[string]struct {
+		kind     poolKind
+		importId string
+		wantID   string
+		// wantErr is part of the expected error, empty when no error is expected
+		wantErr string
+	}{
+		"AWS pool sharing its name with a basic pool": {
+			kind:     awsPoolKind,
+			importId: "name:Desktops",
+			wantID:   "1",
+		},
+		"basic pool sharing its name with an AWS pool": {
+			kind:     basicPoolKind,
+			importId: "name:Desktops",
+			wantID:   "2",
+		},
+		"basic pool without provision config": {
+			kind:     basicPoolKind,
+			importId: "name:Basic only",
+			wantID:   "3",
+		},
+		"name of a pool of the other type": {
+			kind:     awsPoolKind,
+			importId: "name:Basic only",
+			wantErr:  `No AWS pool found with the name "Basic only".`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &poolResource{kind: test.kind, client: client}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			state := tfsdk.State{Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil), Schema: schemaResp.Schema}
+			resp := resource.ImportStateResponse{State: state}
+
+			r.ImportState(ctx, resource.ImportStateRequest{ID: test.importId}, &resp)
+
+			if test.wantErr != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), test.wantErr) {
+					t.Fatalf("expected an error containing %q, got: %v", test.wantErr, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if id.ValueString() != test.wantID {
+				t.Errorf("got id %s, want %s", id, test.wantID)
+			}
+		})
+	}
+}
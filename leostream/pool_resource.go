@@ -7,9 +7,13 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
@@ -18,6 +22,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"gitlab.hocmodo.nl/community/leostream-client-go"
@@ -25,30 +31,30 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &awsPoolResource{}
-	_ resource.ResourceWithConfigure   = &awsPoolResource{}
-	_ resource.ResourceWithImportState = &awsPoolResource{}
+	_ resource.Resource                   = &poolResource{}
+	_ resource.ResourceWithConfigure      = &poolResource{}
+	_ resource.ResourceWithImportState    = &poolResource{}
+	_ resource.ResourceWithValidateConfig = &poolResource{}
+	_ resource.ResourceWithUpgradeState   = &poolResource{}
 )
 
-// NewPoolResource is a helper function to simplify the provider implementation.
-func NewAwsPoolResource() resource.Resource {
-	return &awsPoolResource{}
-}
-
-// poolResource is the resource implementation.
-type awsPoolResource struct {
-	client *leostream.Client
+// poolResource is the resource implementation shared by all pool types, the
+// kind holds what differs between them.
+type poolResource struct {
+	kind   poolKind
+	client apiClient
 }
 
 // Metadata returns the resource type name.
-func (r *awsPoolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_aws_pool"
+func (r *poolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.kind.typeName
 }
 
 // Schema defines the schema for the resource.
-func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *poolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: `The AWS pool resource allows you to manage Leostream AWS pools. These pools are used to group desktops in AWS together for management and provisioning.`,
+		Version:     schemaVersion(poolStateMigrations),
+		Description: r.kind.description,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Unique identifier for the pool.",
@@ -65,6 +71,10 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			"display_name": schema.StringAttribute{
 				Description: "Display name of the pool.",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"notes": schema.StringAttribute{
 				Description: "Notes for the pool.",
@@ -77,29 +87,22 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				Default:     int64default.StaticInt64(0),
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
 			},
 			"pool_definition": schema.SingleNestedAttribute{
 				Description: "Pool definition",
 				Optional:    true,
 				Computed:    true,
 				Default: objectdefault.StaticValue(types.ObjectValueMust(
-					awsPoolDefinitionModel{}.attrTypes(), awsPoolDefinitionModel{}.defaultObject()),
+					poolDefinitionModel{}.attrTypes(), poolDefinitionModel{}.defaultObject()),
 				),
 				Attributes: map[string]schema.Attribute{
 					"restrict_by": schema.StringAttribute{
-						Description: `Restrict by:
-						A = by attribute (default)
-						T = by tag
-						C = by centers
-						E = vSphere hosts
-						L = vSphere clusters
-						V = vSphere resource pools
-						Z = LDAP attributes
-						H = ad hoc list (selection from parent pool)
-						`,
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("C"),
+						Description: r.kind.restrictByDescription(),
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString(r.kind.restrictByDefault),
+						Validators:  []validator.String{stringvalidator.OneOf(r.kind.restrictByValues...)},
 					},
 					"server_ids": schema.ListAttribute{
 						Description: "List of tag IDs defining this pool",
@@ -109,32 +112,34 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						//Set Default to be an empty list
 						Default: listdefault.StaticValue(types.ListNull(types.Int64Type)),
 					},
-					"never_rogue": schema.Int64Attribute{
-						Description: "0 or 1: A boolean field indicating if desktops in this pool treat any user as the assigned user",
+					"never_rogue": schema.BoolAttribute{
+						Description: "A boolean field indicating if desktops in this pool treat any user as the assigned user",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
-					"use_vmotion": schema.Int64Attribute{
-						Description: "0 or 1: A boolean field indicating whether VMs of this pool will vMotion to new host",
+					"use_vmotion": schema.BoolAttribute{
+						Description: "A boolean field indicating whether VMs of this pool will vMotion to new host",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
 					"parent_pool_id": schema.Int64Attribute{
 						Description: "ID of the parent pool",
 						Optional:    true,
 						Computed:    true,
 						Default:     int64default.StaticInt64(1),
+						Validators:  []validator.Int64{int64validator.AtLeast(0)},
 					},
 					"pool_attribute_join": schema.StringAttribute{
 						Description: `A or O: How do the pool attributes get joined:
 						A = And
 						O = Or
 						`,
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("A"),
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("A"),
+						Validators: []validator.String{stringvalidator.OneOf(CONFIG_POOL_ATTRIBUTE_JOIN_VALUES...)},
 					},
 					"attributes": schema.ListNestedAttribute{
 						Description: "Array container for Pool attributes (restrict_by is 'A') or for LDAP attributes (restrict_by is 'Z', requires Active Directory Centers).",
@@ -147,6 +152,7 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 								resp.RequiresReplace = false
 
 							}, "", ""),
+							listplanmodifier.UseStateForUnknown(),
 						},
 						NestedObject: schema.NestedAttributeObject{
 							//Add a PlanModifier to the NestedObject
@@ -191,6 +197,10 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 										resp.RequiresReplace = false
 
 									}, "", "")},
+									Validators: []validator.String{
+										stringvalidator.OneOf(CONFIG_POOL_VM_TABLE_FIELD_VALUES...),
+										stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("ad_attribute_field"), path.MatchRelative().AtParent().AtName("vm_gpu_field")),
+									},
 								},
 								"ad_attribute_field": schema.StringAttribute{
 									Description: `Desktop attribute, mandatory for LDAP attributes,
@@ -205,6 +215,9 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 										resp.RequiresReplace = false
 
 									}, "", "")},
+									Validators: []validator.String{
+										stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("vm_table_field"), path.MatchRelative().AtParent().AtName("vm_gpu_field")),
+									},
 								},
 								"vm_gpu_field": schema.StringAttribute{
 									Description: "The GPU field to search; must be a column in the vm_gpu table. Cannot exist if vm_table_field or ad_attribute_field is populated.",
@@ -217,6 +230,9 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 										resp.RequiresReplace = true
 
 									}, "", "")},
+									Validators: []validator.String{
+										stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("vm_table_field"), path.MatchRelative().AtParent().AtName("ad_attribute_field")),
+									},
 								},
 								"text_to_match": schema.StringAttribute{
 									Description: "The free form text attribute",
@@ -251,6 +267,7 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 										resp.RequiresReplace = false
 
 									}, "", "")},
+									Validators: []validator.String{stringvalidator.OneOf(CONFIG_POOL_CONDITION_TYPE_VALUES...)},
 								},
 							},
 						},
@@ -264,22 +281,26 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional: true,
 				Computed: true,
 				Default: objectdefault.StaticValue(types.ObjectValueMust(
-					awsProvisionModel{}.attrTypes(), awsProvisionModel{}.defaultObject()),
+					r.kind.provisionAttrTypes(), r.kind.provisionDefaultObject()),
 				),
-				Attributes: map[string]schema.Attribute{
-					"provision_on_off": schema.Int64Attribute{
+				// the center specific attributes are added by the provisioner of the pool type
+				Attributes: mergeAttributes(map[string]schema.Attribute{
+					"provision_on_off": schema.BoolAttribute{
 						Description: `
 						A boolean field indicating if state of provisioning for this pool is:
 						Running - provision according to thresholds
 						Stopped - disabled by user or the Broker by error
 						`,
 						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(CONFIG_POOL_PROVISION_ON_OFF),
 					},
 					"provision_max": schema.Int64Attribute{
 						Description: "The maximum number of new machines that will be provisioned when the threshold is reached.",
 						Optional:    true,
 						Computed:    true,
 						Default:     int64default.StaticInt64(0),
+						Validators:  []validator.Int64{int64validator.AtLeast(0)},
 					},
 					"provision_vm_id": schema.Int64Attribute{
 						Description: "The ID of the server which will do the provisioning, or 0 if URL notification only",
@@ -290,12 +311,15 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					"provision_server_id": schema.Int64Attribute{
 						Description: "The ID of the server which will do the provisioning, or 0 if URL notification only",
 						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(CONFIG_POOL_PROVISION_SERVER_ID),
 					},
 					"provision_threshold": schema.Int64Attribute{
 						Description: "Minimum number of available VMs before triggering provisioning.",
 						Optional:    true,
 						Computed:    true,
 						Default:     int64default.StaticInt64(0),
+						Validators:  []validator.Int64{int64validator.AtLeast(0)},
 					},
 					"provision_tenant_id": schema.Int64Attribute{
 						Description: "The tenant to provision into",
@@ -303,17 +327,17 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						Computed:    true,
 						Default:     int64default.StaticInt64(0),
 					},
-					"provision_limits_enforce": schema.Int64Attribute{
-						Description: "0 or 1: A boolean field indicating if Broker creates and deletes virtual machines to meet the start and max threshold.",
+					"provision_limits_enforce": schema.BoolAttribute{
+						Description: "A boolean field indicating if Broker creates and deletes virtual machines to meet the start and max threshold.",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
-					"mark_deletable": schema.Int64Attribute{
-						Description: "0 or 1: Specifies whether to initialize newly-provisioned desktops as 'deletable'.",
+					"mark_deletable": schema.BoolAttribute{
+						Description: "Specifies whether to initialize newly-provisioned desktops as 'deletable'.",
 						Optional:    true,
 						Computed:    true,
-						Default:     int64default.StaticInt64(0),
+						Default:     booldefault.StaticBool(false),
 					},
 					"provision_url": schema.StringAttribute{
 						Description: "The URL to notify when a new machine is provisioned.",
@@ -333,83 +357,25 @@ func (r *awsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						Computed:    true,
 						Default:     stringdefault.StaticString(""),
 					},
-					"center": schema.SingleNestedAttribute{
-						Description: `Container for parameters related to Center. Offically:
-						Google (object)
-						or RHEV (object)
-						or Scale (object)
-						or OpenStack (object)
-						or (Amazon AWS (Provision from image (object)
-						or Provision from launch template (object)))
-						or Azure (object)
-						or vCenter (object)
-						or ProvisionCenter (null) (ProvisionCenter).
-						!This versoim of the provider only supports AWS.`,
-						Optional: false,
-						Required: true,
-						Attributes: map[string]schema.Attribute{
-							"id": schema.Int64Attribute{
-								Description: "Unique identifier for the center.",
-								Optional:    true,
-							},
-							"name": schema.StringAttribute{
-								Description: "Name of the center.",
-								Optional:    true,
-								Computed:    false,
-							},
-							"type": schema.StringAttribute{
-								Description: "Type of the center. Currently only AWS is supported: amazon",
-								Optional:    true,
-								Computed:    true,
-								Default:     stringdefault.StaticString(""),
-							},
-							"aws_size": schema.StringAttribute{
-								Description: `The size of the instance to provision.
-								eg. t2.micro`,
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString(""),
-							},
-							"provision_method": schema.StringAttribute{
-								Description: "The method of provisioning. Currently only 'image' is supported.",
-								Optional:    true,
-								Computed:    true,
-								Default:     stringdefault.StaticString("image"),
-							},
-							"aws_iam_name": schema.StringAttribute{
-								Description: "The name of the IAM role to use for the instance.",
-								Optional:    true,
-								Computed:    true,
-								Default:     stringdefault.StaticString(""),
-							},
-							"aws_sub_net": schema.StringAttribute{
-								Description: "The subnet ID to use for the instance.",
-								Optional:    true,
-								Computed:    true,
-								Default:     stringdefault.StaticString(""),
-							},
-							"aws_sec_group": schema.StringAttribute{
-								Description: "The security group name to use for the instance.",
-								Optional:    true,
-								Computed:    true,
-								Default:     stringdefault.StaticString(""),
-							},
-							"aws_vpc_id": schema.StringAttribute{
-								Description: "The VPC ID to use for the instance.",
-								Optional:    true,
-								Computed:    true,
-								Default:     stringdefault.StaticString(""),
-							},
-						},
-					},
-				},
+				}, r.kind.provisioner.schemaAttributes()),
 			},
 		},
 	}
 }
 
+// ValidateConfig validates combinations of attributes that depend on each other.
+func (r *poolResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validatePoolAttributes(ctx, req.Config, &resp.Diagnostics)
+	r.kind.provisioner.validateConfig(ctx, req.Config, &resp.Diagnostics)
+}
+
+// UpgradeState migrates state written by earlier schema versions.
+func (r *poolResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(poolStateMigrations)
+}
+
 // Configure adds the provider configured client to the resource.
-func (r *awsPoolResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+func (r *poolResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
@@ -425,14 +391,14 @@ func (r *awsPoolResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	r.client = client
+	r.client = leostreamAPI{client: client}
 }
 
 // Create a new resource.
-func (r *awsPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func (r *poolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// retrieve values from plan
 
-	var plan awsPoolResourceModel
+	var plan poolResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -440,33 +406,33 @@ func (r *awsPoolResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// empty state as it's a create operation
-	var state awsPoolResourceModel
+	var state poolResourceModel
 
 	// defer to common function to create or update the resource
 
 	PlStored := r.CreateNested(ctx, &plan, &state, &resp.Diagnostics)
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Map response body to schema and populate Computed attribute values
-	// convert int64 to string
-	plan.ID = types.StringValue(strconv.FormatInt(PlStored.Stored_data.ID, 10))
+	id := strconv.FormatInt(PlStored.Stored_data.ID, 10)
 
-	// set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
+	// Keep the ID of the new pool in state first, so a failed read back leaves
+	// a tainted resource that Terraform replaces instead of an orphaned pool
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// set state to the pool as stored by the broker
+	r.readBack(ctx, id, req.Plan, &resp.State, &resp.Diagnostics)
 }
 
 // Read resource information.
-func (r *awsPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+func (r *poolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 
 	// Retrieve values from state
-	var state awsPoolResourceModel
+	var state poolResourceModel
 	tflog.Info(ctx, "Performing state get on pool resource")
 
 	diags := req.State.Get(ctx, &state)
@@ -478,16 +444,19 @@ func (r *awsPoolResource) Read(ctx context.Context, req resource.ReadRequest, re
 	tflog.Info(ctx, "Performing Read on pool resource")
 
 	// // use common model for state
-	var newState awsPoolResourceModel
+	var newState poolResourceModel
 	// use common Read function
-	newState.Read(ctx, *r.client, &resp.Diagnostics, "resource", state.ID.ValueString())
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
+	found := newState.Read(ctx, r.client, r.kind, &resp.Diagnostics, "resource", state.ID.ValueString())
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// populate internal fields into new state
-	newState.ID = state.ID
+	// remove the resource from state when it was deleted outside Terraform, so it is planned for creation
+	if !found {
+		tflog.Warn(ctx, "Leostream pool not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	//set refreshed state
 	diags = resp.State.Set(ctx, &newState)
@@ -498,10 +467,10 @@ func (r *awsPoolResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 }
 
-func (r *awsPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+func (r *poolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 
 	// retrieve values from plan
-	var plan awsPoolResourceModel
+	var plan poolResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -509,7 +478,7 @@ func (r *awsPoolResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	// retrieve values from state
-	var state awsPoolResourceModel
+	var state poolResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -517,24 +486,18 @@ func (r *awsPoolResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	r.UpdateNested(ctx, &plan, &state, &resp.Diagnostics)
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	// update state
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// update state to the pool as stored by the broker
+	r.readBack(ctx, plan.ID.ValueString(), req.Plan, &resp.State, &resp.Diagnostics)
 }
 
-func (r *awsPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+func (r *poolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 
 	// Retrieve values from state
-	var state awsPoolResourceModel
+	var state poolResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -543,7 +506,7 @@ func (r *awsPoolResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	// Delete existing pool
 	err := r.client.DeletePool(state.ID.ValueString(), nil)
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Error Deleting Leostream Pool",
 			"Could not delete pool, unexpected error: "+err.Error(),
@@ -553,7 +516,95 @@ func (r *awsPoolResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 }
 
-func (r *awsPoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+func (r *poolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// import by numeric ID or by name
+	name, _ := importName(req.ID)
+	importStateByIdOrName(ctx, req, resp, r.kind.label, func() ([]importCandidate, error) {
+		pools, err := r.client.GetPools()
+		if err != nil {
+			return nil, err
+		}
+
+		// The pool list has no pool type, so the pools with the name are read to
+		// skip the pools of the other pool types
+		var candidates []importCandidate
+		for _, pool := range pools {
+			if pool.Name != name {
+				continue
+			}
+			details, err := r.client.GetPool(strconv.FormatInt(pool.ID, 10))
+			if err != nil {
+				return nil, err
+			}
+			if !r.kind.provisioner.matches(details.Provision) {
+				continue
+			}
+			candidates = append(candidates, importCandidate{ID: int64(pool.ID), Name: pool.Name})
+		}
+		return candidates, nil
+	})
+}
+
+// `Create` function for the resource
+func (r *poolResource) CreateNested(ctx context.Context, plan *poolResourceModel, state *poolResourceModel, diags *diag.Diagnostics) *leostream.PoolsStored {
+	poolConfig := poolConfigFromPlan(ctx, plan, r.kind, diags)
+	if poolConfig == nil {
+		return nil
+	}
+
+	// Create new pool
+	PoolsStored, err := r.client.CreatePool(*poolConfig, nil)
+
+	if err != nil {
+		diags.AddError(
+			"Unable to Create Pool",
+			err.Error(),
+		)
+		return nil
+	} else {
+		return PoolsStored
+	}
+}
+
+// `Update` function for the resource
+func (r *poolResource) UpdateNested(ctx context.Context, plan *poolResourceModel, state *poolResourceModel, diags *diag.Diagnostics) *leostream.PoolsStored {
+	poolConfig := poolConfigFromPlan(ctx, plan, r.kind, diags)
+	if poolConfig == nil {
+		return nil
+	}
+
+	tflog.Info(ctx, "Performing Update via pool common")
+
+	// Update pool
+	PoolsStored, err := r.client.UpdatePool(plan.ID.ValueString(), *poolConfig, nil)
+
+	if err != nil {
+		diags.AddError(
+			"Unable to Update Pool",
+			err.Error(),
+		)
+		return nil
+	} else {
+		return PoolsStored
+	}
+}
+
+// readBack reads the pool from the Leostream API after a create or update and
+// sets the state, warning about attributes that were not stored as planned.
+func (r *poolResource) readBack(ctx context.Context, id string, plan tfsdk.Plan, state *tfsdk.State, diags *diag.Diagnostics) {
+	var newState poolResourceModel
+	found := newState.Read(ctx, r.client, r.kind, diags, "resource", id)
+	if diags.HasError() {
+		return
+	}
+
+	if !found {
+		diags.AddError(
+			"Unable to Read Back Pool",
+			"Pool "+id+" was not found in Leostream right after it was written.",
+		)
+		return
+	}
+
+	setReadBackState(ctx, "pool", &newState, plan, state, diags)
 }
@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccProtoV6ProviderFactories starts the provider in-process for acceptance
+// tests. Together with a testBroker the acceptance tests run without a broker,
+// only the Terraform CLI is required:
+//
+//	make testacc
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"leostream": providerserver.NewProtocol6WithError(New()),
+}
+
+// testAccRemovedConfig removes a resource from state without destroying it, so a
+// following step can import it into an empty state with ImportStatePersist.
+func testAccRemovedConfig(broker *testBroker, address string) string {
+	return broker.providerConfig() + fmt.Sprintf(`
+removed {
+  from = %s
+
+  lifecycle {
+    destroy = false
+  }
+}
+`, address)
+}
+
+func TestAccProviderProxyHeaders(t *testing.T) {
+	broker := newTestBroker(t)
+
+	// The broker behind the proxy is reached over HTTPS, so the proxy opens a tunnel with CONNECT
+	var mu sync.Mutex
+	var brokerHeaders, proxyHeaders http.Header
+	tlsBroker := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		brokerHeaders = r.Header.Clone()
+		mu.Unlock()
+		broker.serveHTTP(w, r)
+	}))
+	defer tlsBroker.Close()
+
+	// Requests to loopback addresses never use a proxy, the proxy connects every tunnel to the broker instead
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "only CONNECT is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		mu.Lock()
+		proxyHeaders = r.Header.Clone()
+		mu.Unlock()
+
+		upstream, err := net.Dial("tcp", tlsBroker.Listener.Addr().String())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() {
+			_, _ = io.Copy(upstream, conn)
+			upstream.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(conn, upstream)
+			conn.Close()
+		}()
+	}))
+	defer proxy.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "leostream" {
+  host                 = "https://broker.example.com"
+  username             = %q
+  password             = %q
+  insecure_skip_verify = true
+  proxy_url            = %q
+
+  custom_headers = {
+    X-Broker-Tenant = "tenant-1"
+  }
+  proxy_headers = {
+    Proxy-Authorization = "Basic cHJveHk6c2VjcmV0"
+  }
+}
+
+data "leostream_gateways" "test" {}
+`, broker.username, broker.password, proxy.URL),
+				Check: func(*terraform.State) error {
+					mu.Lock()
+					defer mu.Unlock()
+
+					if proxyHeaders.Get("Proxy-Authorization") == "" {
+						return fmt.Errorf("proxy_headers were not sent to the proxy")
+					}
+					if proxyHeaders.Get("X-Broker-Tenant") != "" {
+						return fmt.Errorf("custom_headers were sent to the proxy")
+					}
+					if brokerHeaders.Get("X-Broker-Tenant") != "tenant-1" {
+						return fmt.Errorf("custom_headers were not sent to the broker")
+					}
+					if brokerHeaders.Get("Proxy-Authorization") != "" {
+						return fmt.Errorf("proxy_headers were sent to the broker")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestStringValueOrDeprecatedEnv(t *testing.T) {
+	tests := map[string]struct {
+		value      types.String
+		env        string
+		deprecated string
+		want       string
+		wantWarn   bool
+	}{
+		"configuration wins over both environment variables": {
+			value:      types.StringValue("https://config.example.com"),
+			env:        "https://env.example.com",
+			deprecated: "https://deprecated.example.com",
+			want:       "https://config.example.com",
+		},
+		"LEOSTREAM_HOST wins over leostream_HOST": {
+			value:      types.StringNull(),
+			env:        "https://env.example.com",
+			deprecated: "https://deprecated.example.com",
+			want:       "https://env.example.com",
+		},
+		"leostream_HOST is used with a warning": {
+			value:      types.StringNull(),
+			deprecated: "https://deprecated.example.com",
+			want:       "https://deprecated.example.com",
+			wantWarn:   true,
+		},
+		"configured empty string does not fall back to leostream_HOST": {
+			value:      types.StringValue(""),
+			deprecated: "https://deprecated.example.com",
+			want:       "",
+		},
+		"nothing set": {
+			value: types.StringNull(),
+			want:  "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("LEOSTREAM_HOST", test.env)
+			t.Setenv("leostream_HOST", test.deprecated)
+
+			var diags diag.Diagnostics
+			got := stringValueOrDeprecatedEnv(test.value, "LEOSTREAM_HOST", "leostream_HOST", &diags)
+
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			if diags.HasError() {
+				t.Errorf("unexpected errors: %v", diags)
+			}
+			if gotWarn := diags.WarningsCount() > 0; gotWarn != test.wantWarn {
+				t.Errorf("got warning %t, want %t: %v", gotWarn, test.wantWarn, diags)
+			}
+			if test.wantWarn && !strings.Contains(diags.Warnings()[0].Detail(), "Use the LEOSTREAM_HOST environment variable instead") {
+				t.Errorf("warning does not name the replacement: %s", diags.Warnings()[0].Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,440 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gitlab.hocmodo.nl/community/leostream-client-go"
+)
+
+// fakeClient is an in-memory apiClient. Writes fail with writeErr and reads
+// fail with readErr when set, reads of a missing object fail like the Leostream
+// API with a not-found response.
+type fakeClient struct {
+	pools    map[string]leostream.Pool
+	centers  map[string]leostream.Center
+	gateways map[string]leostream.Gateway
+
+	writeErr error
+	readErr  error
+	writes   int
+	reads    int
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		pools:    map[string]leostream.Pool{},
+		centers:  map[string]leostream.Center{},
+		gateways: map[string]leostream.Gateway{},
+	}
+}
+
+func (c *fakeClient) write() error {
+	c.writes++
+	return c.writeErr
+}
+
+func (c *fakeClient) read() error {
+	c.reads++
+	return c.readErr
+}
+
+// notFound returns the error of the Leostream API for a missing object.
+func notFound(id string) error {
+	return newAPIError(fmt.Errorf(`status: %d, body: {"error": "object %s not found"}`, http.StatusNotFound, id))
+}
+
+func (c *fakeClient) GetPools() ([]leostream.PoolList, error) {
+	var pools []leostream.PoolList
+	for id, pool := range c.pools {
+		n, _ := strconv.ParseInt(id, 10, 64)
+		pools = append(pools, leostream.PoolList{ID: n, Name: pool.Name})
+	}
+	return pools, c.readErr
+}
+
+func (c *fakeClient) GetPool(id string) (*leostream.Pool, error) {
+	if err := c.read(); err != nil {
+		return nil, err
+	}
+	pool, ok := c.pools[id]
+	if !ok {
+		return nil, notFound(id)
+	}
+	return &pool, nil
+}
+
+func (c *fakeClient) CreatePool(pool leostream.Pool, _ *string) (*leostream.PoolsStored, error) {
+	if err := c.write(); err != nil {
+		return nil, err
+	}
+	stored := &leostream.PoolsStored{}
+	stored.Stored_data.ID = int64(len(c.pools) + 1)
+	c.pools[strconv.FormatInt(stored.Stored_data.ID, 10)] = pool
+	return stored, nil
+}
+
+func (c *fakeClient) UpdatePool(id string, pool leostream.Pool, _ *string) (*leostream.PoolsStored, error) {
+	if err := c.write(); err != nil {
+		return nil, err
+	}
+	c.pools[id] = pool
+	return &leostream.PoolsStored{}, nil
+}
+
+func (c *fakeClient) DeletePool(id string, _ *string) error {
+	if err := c.write(); err != nil {
+		return err
+	}
+	if _, ok := c.pools[id]; !ok {
+		return notFound(id)
+	}
+	delete(c.pools, id)
+	return nil
+}
+
+func (c *fakeClient) GetCenters() ([]leostream.CenterList, error) {
+	var centers []leostream.CenterList
+	for id, center := range c.centers {
+		n, _ := strconv.ParseInt(id, 10, 64)
+		centers = append(centers, leostream.CenterList{ID: n, Name: center.Center_definition.Name})
+	}
+	return centers, c.readErr
+}
+
+func (c *fakeClient) GetCenter(id string) (*leostream.Center, error) {
+	if err := c.read(); err != nil {
+		return nil, err
+	}
+	center, ok := c.centers[id]
+	if !ok {
+		return nil, notFound(id)
+	}
+	return &center, nil
+}
+
+func (c *fakeClient) CreateCenter(center leostream.Center, _ *string) (*leostream.CenterStored, error) {
+	if err := c.write(); err != nil {
+		return nil, err
+	}
+	stored := &leostream.CenterStored{}
+	stored.Stored_data.ID = int64(len(c.centers) + 1)
+	c.centers[strconv.FormatInt(stored.Stored_data.ID, 10)] = center
+	return stored, nil
+}
+
+func (c *fakeClient) UpdateCenter(id string, center leostream.Center, _ *string) (*leostream.CenterStored, error) {
+	if err := c.write(); err != nil {
+		return nil, err
+	}
+	c.centers[id] = center
+	return &leostream.CenterStored{}, nil
+}
+
+func (c *fakeClient) DeleteCenter(id string, _ *string) error {
+	if err := c.write(); err != nil {
+		return err
+	}
+	if _, ok := c.centers[id]; !ok {
+		return notFound(id)
+	}
+	delete(c.centers, id)
+	return nil
+}
+
+func (c *fakeClient) GetGateways() ([]leostream.Gateway, error) {
+	var gateways []leostream.Gateway
+	for _, gateway := range c.gateways {
+		gateways = append(gateways, gateway)
+	}
+	return gateways, c.readErr
+}
+
+func (c *fakeClient) GetGateway(id string) (*leostream.Gateway, error) {
+	if err := c.read(); err != nil {
+		return nil, err
+	}
+	gateway, ok := c.gateways[id]
+	if !ok {
+		return nil, notFound(id)
+	}
+	return &gateway, nil
+}
+
+func (c *fakeClient) CreateGateway(gateway leostream.Gateway, _ *string) (*leostream.GatewayStored, error) {
+	if err := c.write(); err != nil {
+		return nil, err
+	}
+	stored := &leostream.GatewayStored{}
+	stored.Stored_data.ID = int64(len(c.gateways) + 1)
+	gateway.ID = stored.Stored_data.ID
+	c.gateways[strconv.FormatInt(stored.Stored_data.ID, 10)] = gateway
+	return stored, nil
+}
+
+func (c *fakeClient) UpdateGateway(id string, gateway leostream.Gateway, _ *string) (*leostream.GatewayStored, error) {
+	if err := c.write(); err != nil {
+		return nil, err
+	}
+	c.gateways[id] = gateway
+	return &leostream.GatewayStored{}, nil
+}
+
+func (c *fakeClient) DeleteGateway(id string, _ *string) error {
+	if err := c.write(); err != nil {
+		return err
+	}
+	if _, ok := c.gateways[id]; !ok {
+		return notFound(id)
+	}
+	delete(c.gateways, id)
+	return nil
+}
+
+// testPool returns a pool as returned by the Leostream API.
+func testPool() leostream.Pool {
+	return leostream.Pool{
+		ID:              1,
+		Name:            "pool",
+		Pool_definition: &leostream.PoolDefinition{Restrict_by: "C"},
+		Provision:       &leostream.Provision{Center: &leostream.PoolAwsCenter{}},
+	}
+}
+
+// errorCase is a resource backed by a fake client holding one object with ID 1,
+// together with the state Terraform holds for that object.
+type errorCase struct {
+	resource resource.Resource
+	client   *fakeClient
+	state    tfsdk.State
+}
+
+func newErrorCases(t *testing.T) map[string]func() errorCase {
+	t.Helper()
+
+	return map[string]func() errorCase{
+		"aws_pool": func() errorCase {
+			client := newFakeClient()
+			client.pools["1"] = testPool()
+			r := &poolResource{kind: awsPoolKind, client: client}
+			var model poolResourceModel
+			return errorCase{r, client, readErrorCaseState(t, r, func(diags *diag.Diagnostics) bool {
+				return model.Read(context.Background(), client, awsPoolKind, diags, "resource", "1")
+			}, &model)}
+		},
+		"basic_pool": func() errorCase {
+			client := newFakeClient()
+			client.pools["1"] = testPool()
+			r := &poolResource{kind: basicPoolKind, client: client}
+			var model poolResourceModel
+			return errorCase{r, client, readErrorCaseState(t, r, func(diags *diag.Diagnostics) bool {
+				return model.Read(context.Background(), client, basicPoolKind, diags, "resource", "1")
+			}, &model)}
+		},
+		"center": func() errorCase {
+			client := newFakeClient()
+			client.centers["1"] = leostream.Center{Center_definition: leostream.CenterDefinition{Name: "center", Type: "amazon"}}
+			r := &centerResource{client: client}
+			var model centerResourceModel
+			return errorCase{r, client, readErrorCaseState(t, r, func(diags *diag.Diagnostics) bool {
+				return model.Read(context.Background(), client, diags, "resource", "1")
+			}, &model)}
+		},
+		"gateway": func() errorCase {
+			client := newFakeClient()
+			client.gateways["1"] = leostream.Gateway{ID: 1, Name: "gateway", Address: "gateway.example.com"}
+			r := &gatewayResource{client: client}
+			var model gatewayResourceModel
+			return errorCase{r, client, readErrorCaseState(t, r, func(diags *diag.Diagnostics) bool {
+				return model.Read(context.Background(), client, diags, "1")
+			}, &model)}
+		},
+	}
+}
+
+// readErrorCaseState reads the object into the model and returns it as state.
+func readErrorCaseState(t *testing.T, r resource.Resource, read func(*diag.Diagnostics) bool, model any) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	var diags diag.Diagnostics
+	if !read(&diags) || diags.HasError() {
+		t.Fatalf("reading object: %v", diags)
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+	return state
+}
+
+func TestCreateErrorLeavesNoState(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		fail func(*fakeClient)
+		// wantID is the ID kept in state, empty when no state may be written
+		wantID string
+	}{
+		"create fails": {
+			fail: func(c *fakeClient) { c.writeErr = errors.New("broker unavailable") },
+		},
+		"read back fails": {
+			fail:   func(c *fakeClient) { c.readErr = errors.New("broker unavailable") },
+			wantID: "2",
+		},
+	}
+
+	for failure, test := range tests {
+		for name, newCase := range newErrorCases(t) {
+			t.Run(failure+"/"+name, func(t *testing.T) {
+				c := newCase()
+				test.fail(c.client)
+
+				schema := c.state.Schema
+				null := tftypes.NewValue(schema.Type().TerraformType(ctx), nil)
+				req := resource.CreateRequest{Plan: tfsdk.Plan{Raw: c.state.Raw, Schema: schema}}
+				resp := resource.CreateResponse{State: tfsdk.State{Raw: null, Schema: schema}}
+
+				c.resource.Create(ctx, req, &resp)
+
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+
+				if test.wantID == "" {
+					if !resp.State.Raw.Equal(null) {
+						t.Errorf("state was written after a failed create: %s", resp.State.Raw)
+					}
+					return
+				}
+
+				// The object was created, its ID must be kept so it is not orphaned
+				var id types.String
+				if diags := resp.State.GetAttribute(ctx, path.Root("id"), &id); diags.HasError() {
+					t.Fatalf("reading id: %v", diags)
+				}
+				if id.ValueString() != test.wantID {
+					t.Errorf("got id %s in state, want %s", id, test.wantID)
+				}
+			})
+		}
+	}
+}
+
+func TestMissingObjectRemovesState(t *testing.T) {
+	ctx := context.Background()
+
+	for name, newCase := range newErrorCases(t) {
+		t.Run(name, func(t *testing.T) {
+			c := newCase()
+
+			// The object was deleted outside Terraform
+			clear(c.client.pools)
+			clear(c.client.centers)
+			clear(c.client.gateways)
+
+			readResp := resource.ReadResponse{State: c.state}
+			c.resource.Read(ctx, resource.ReadRequest{State: c.state}, &readResp)
+
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected read error: %v", readResp.Diagnostics)
+			}
+			if !readResp.State.Raw.IsNull() {
+				t.Errorf("state was kept for a missing object: %s", readResp.State.Raw)
+			}
+
+			deleteResp := resource.DeleteResponse{State: c.state}
+			c.resource.Delete(ctx, resource.DeleteRequest{State: c.state}, &deleteResp)
+
+			if deleteResp.Diagnostics.HasError() {
+				t.Errorf("unexpected delete error: %v", deleteResp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestUpdateErrorKeepsPriorState(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		fail      func(*fakeClient)
+		wantReads int
+	}{
+		"update fails": {
+			fail: func(c *fakeClient) { c.writeErr = errors.New("broker unavailable") },
+		},
+		"read back fails": {
+			fail:      func(c *fakeClient) { c.readErr = errors.New("broker unavailable") },
+			wantReads: 1,
+		},
+	}
+
+	for failure, test := range tests {
+		for name, newCase := range newErrorCases(t) {
+			t.Run(failure+"/"+name, func(t *testing.T) {
+				c := newCase()
+				c.client.reads = 0
+				test.fail(c.client)
+
+				// The framework starts an update with the prior state
+				prior := c.state.Raw.Copy()
+				req := resource.UpdateRequest{
+					Plan:  tfsdk.Plan{Raw: c.state.Raw, Schema: c.state.Schema},
+					State: c.state,
+				}
+				resp := resource.UpdateResponse{State: tfsdk.State{Raw: prior, Schema: c.state.Schema}}
+
+				c.resource.Update(ctx, req, &resp)
+
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				if !resp.State.Raw.Equal(prior) {
+					t.Errorf("state was changed after a failed update: %s", resp.State.Raw)
+				}
+				if c.client.reads != test.wantReads {
+					t.Errorf("got %d API reads, want %d", c.client.reads, test.wantReads)
+				}
+			})
+		}
+	}
+}
+
+func TestUpdateConversionErrorSkipsApiCall(t *testing.T) {
+	ctx := context.Background()
+	c := newErrorCases(t)["aws_pool"]()
+
+	// A plan that does not match the schema can not be converted to a pool
+	plan := tfsdk.Plan{
+		Raw:    tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, map[string]tftypes.Value{}),
+		Schema: c.state.Schema,
+	}
+	req := resource.UpdateRequest{Plan: plan, State: c.state}
+	resp := resource.UpdateResponse{State: c.state}
+
+	c.resource.Update(ctx, req, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	if c.client.writes != 0 {
+		t.Errorf("got %d API writes, want none", c.client.writes)
+	}
+}
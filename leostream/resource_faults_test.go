@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// faultScenario describes a resource for the broker fault scenarios. The
+// configuration only differs in the value of a notes attribute, so every step
+// is an in-place update.
+type faultScenario struct {
+	resourceName string
+	collection   string
+	config       func(notes string) string
+
+	// notesAttribute and notesField are the notes in the state and in the broker object
+	notesAttribute string
+	notesField     string
+
+	updateError *regexp.Regexp
+}
+
+// faultSteps returns the steps that run the resource against a misbehaving broker.
+func faultSteps(broker *testBroker, s faultScenario) []resource.TestStep {
+	objectPath := s.collection + "/1"
+	var updates int
+
+	return []resource.TestStep{
+		// A slow broker only slows down the apply
+		{
+			PreConfig: func() {
+				broker.inject(brokerFault{times: 10, delay: 200 * time.Millisecond})
+			},
+			Config: s.config("created"),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(s.resourceName, "id", "1"),
+				resource.TestCheckResourceAttr(s.resourceName, s.notesAttribute, "created"),
+			),
+		},
+		// A failed update keeps the prior state
+		{
+			PreConfig: func() {
+				broker.inject(brokerFault{method: http.MethodPut, path: objectPath, times: 1, status: http.StatusInternalServerError})
+			},
+			Config:      s.config("not stored"),
+			ExpectError: s.updateError,
+		},
+		{
+			Config:   s.config("created"),
+			PlanOnly: true,
+		},
+		// An expired session is renewed and the request retried
+		{
+			PreConfig: func() {
+				updates = broker.requestCount(http.MethodPut, objectPath)
+				broker.inject(brokerFault{method: http.MethodPut, path: objectPath, times: 1, expireSession: true})
+			},
+			Config: s.config("renewed"),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(s.resourceName, s.notesAttribute, "renewed"),
+				broker.checkField(s.collection, 1, s.notesField, "renewed"),
+				func(_ *terraform.State) error {
+					// the update rejected with the expired session and its retry
+					if got := broker.requestCount(http.MethodPut, objectPath) - updates; got != 2 {
+						return fmt.Errorf("got %d update requests, want 2", got)
+					}
+					return nil
+				},
+			),
+		},
+		// An update the broker only partially applies is reported as a warning, the
+		// state keeps the planned value and the next plan shows the difference
+		{
+			PreConfig: func() {
+				broker.inject(brokerFault{method: http.MethodPut, path: objectPath, times: 1, ignore: []string{s.notesField}})
+			},
+			Config:             s.config("partial"),
+			ExpectNonEmptyPlan: true,
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(s.resourceName, s.notesAttribute, "partial"),
+				broker.checkField(s.collection, 1, s.notesField, "renewed"),
+			),
+		},
+		{
+			Config:             s.config("partial"),
+			PlanOnly:           true,
+			ExpectNonEmptyPlan: true,
+		},
+		// An object deleted outside Terraform is created again
+		{
+			PreConfig: func() {
+				broker.remove(s.collection, 1)
+			},
+			Config: s.config("partial"),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr(s.resourceName, "id", "2"),
+				resource.TestCheckResourceAttr(s.resourceName, s.notesAttribute, "partial"),
+				broker.checkField(s.collection, 2, s.notesField, "partial"),
+			),
+		},
+	}
+}
+
+// checkField checks a field, in dotted notation, of an object stored on the broker.
+func (b *testBroker) checkField(collection string, id int64, field string, want string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		object, ok := b.get(collection, id)
+		if !ok {
+			return fmt.Errorf("%s %d not found on the broker", collection, id)
+		}
+
+		var value any = object
+		for _, name := range strings.Split(field, ".") {
+			parent, _ := value.(map[string]any)
+			value = parent[name]
+		}
+		if value != want {
+			return fmt.Errorf("%s %d has %s %v on the broker, want %q", collection, id, field, value, want)
+		}
+		return nil
+	}
+}
+
+func TestAccGatewayResourceFaults(t *testing.T) {
+	broker := newTestBroker(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: faultSteps(broker, faultScenario{
+			resourceName: "leostream_gateway.test",
+			collection:   "gateways",
+			config: func(notes string) string {
+				return broker.providerConfig() + fmt.Sprintf(`
+resource "leostream_gateway" "test" {
+  name    = "gateway_us_east_1"
+  address = "gateway.example.com"
+  notes   = %q
+}
+`, notes)
+			},
+			notesAttribute: "notes",
+			notesField:     "notes",
+			updateError:    regexp.MustCompile(`Error Updating Leostream Gateway`),
+		}),
+		CheckDestroy: broker.checkDestroyed("gateways"),
+	})
+}
+
+func TestAccCenterResourceFaults(t *testing.T) {
+	broker := newTestBroker(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: faultSteps(broker, faultScenario{
+			resourceName: "leostream_center.test",
+			collection:   "centers",
+			config: func(notes string) string {
+				return broker.providerConfig() + fmt.Sprintf(`
+resource "leostream_center" "test" {
+  center_definition = {
+    name        = "aws-center-us-east-1"
+    type        = "amazon"
+    vc_password = "aws secret key"
+    notes       = %q
+  }
+}
+`, notes)
+			},
+			notesAttribute: "center_definition.notes",
+			notesField:     "center_definition.notes",
+			updateError:    regexp.MustCompile(`Unable to modify center`),
+		}),
+		CheckDestroy: broker.checkDestroyed("centers"),
+	})
+}
+
+func TestAccAwsPoolResourceFaults(t *testing.T) {
+	broker := newTestBroker(t)
+	centerID := broker.put("centers", map[string]any{"center_definition": map[string]any{"name": "aws-center-us-east-1", "type": "amazon"}})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: faultSteps(broker, faultScenario{
+			resourceName: "leostream_aws_pool.test",
+			collection:   "pools",
+			config: func(notes string) string {
+				return broker.providerConfig() + fmt.Sprintf(`
+resource "leostream_aws_pool" "test" {
+  name  = "AWS desktop pool 1"
+  notes = %q
+
+  pool_definition = {
+    restrict_by = "C"
+  }
+
+  provision = {
+    center = {
+      id   = %d
+      name = "aws-center-us-east-1"
+      type = "amazon"
+    }
+  }
+}
+`, notes, centerID)
+			},
+			notesAttribute: "notes",
+			notesField:     "notes",
+			updateError:    regexp.MustCompile(`Unable to Update Pool`),
+		}),
+		CheckDestroy: broker.checkDestroyed("pools"),
+	})
+}
+
+func TestAccBasicPoolResourceFaults(t *testing.T) {
+	broker := newTestBroker(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: faultSteps(broker, faultScenario{
+			resourceName: "leostream_basic_pool.test",
+			collection:   "pools",
+			config: func(notes string) string {
+				return broker.providerConfig() + fmt.Sprintf(`
+resource "leostream_basic_pool" "test" {
+  name  = "Basic desktop pool 1"
+  notes = %q
+}
+`, notes)
+			},
+			notesAttribute: "notes",
+			notesField:     "notes",
+			updateError:    regexp.MustCompile(`Unable to Update Pool`),
+		}),
+		CheckDestroy: broker.checkDestroyed("pools"),
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Secrets like passwords and keys are never returned by the Leostream API, it
+// returns CONFIG_MASKED_SECRET instead. Resources with credentials keep the
+// configured value in state and pair every secret with an optional
+// `<secret>_version` attribute. Changing the version resends the secret to the
+// broker, which allows rotating a credential that was changed outside Terraform.
+
+// secretFromApi returns the state value for a secret read from the API. A masked
+// or empty value from the API keeps the prior value, a mask in the prior value
+// (state written by older provider versions) is dropped.
+func secretFromApi(apiValue string, prior types.String) types.String {
+	if apiValue != CONFIG_MASKED_SECRET && apiValue != "" {
+		return types.StringValue(apiValue)
+	}
+
+	if prior.IsUnknown() || prior.ValueString() == CONFIG_MASKED_SECRET {
+		return types.StringNull()
+	}
+
+	return prior
+}
+
+// secretChanged reports whether a secret has to be sent to the API on update,
+// either because the value changed or because its version was changed.
+func secretChanged(plan types.String, state types.String, planVersion types.Int64, stateVersion types.Int64) bool {
+	if plan.IsNull() || plan.IsUnknown() {
+		return false
+	}
+
+	return !plan.Equal(state) || !planVersion.Equal(stateVersion)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab.hocmodo.nl/community/leostream-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &sessionEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &sessionEphemeralResource{}
+)
+
+// sessionData is passed from the provider to the session ephemeral resource: the
+// shared client for the credentials of the provider, and the host and transport to
+// log in with the TLS, proxy, header and throttling settings of the provider.
+type sessionData struct {
+	client    *leostream.Client
+	host      string
+	transport http.RoundTripper
+}
+
+// NewSessionEphemeralResource is a helper function to simplify the provider implementation.
+func NewSessionEphemeralResource() ephemeral.EphemeralResource {
+	return &sessionEphemeralResource{}
+}
+
+// sessionEphemeralResource opens a Leostream session of its own, to hand it to
+// other tools without storing the token in the plan or state.
+type sessionEphemeralResource struct {
+	data *sessionData
+}
+
+// sessionEphemeralResourceModel maps the ephemeral resource schema data.
+type sessionEphemeralResourceModel struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	Token    types.String `tfsdk:"token"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *sessionEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *sessionEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Opens a Leostream session for other tools. The session ends when it times out on the broker.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Description: "Username to log in with. Defaults to the username of the provider.",
+				Optional:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password to log in with. Defaults to the password of the provider.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"token": schema.StringAttribute{
+				Description: "Session token, sent in the Authorization header of Leostream REST API requests.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured host and transport to the ephemeral resource.
+func (r *sessionEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*sessionData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *sessionData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.data = data
+}
+
+// Open logs in to the broker and returns the session token.
+func (r *sessionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config sessionEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	username := r.data.client.Auth.Username
+	if !config.Username.IsNull() {
+		username = config.Username.ValueString()
+	}
+
+	password := r.data.client.Auth.Password
+	if !config.Password.IsNull() {
+		password = config.Password.ValueString()
+	}
+
+	if username == "" || password == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Missing Leostream Credentials",
+			"The session needs a username and password. Set them on the ephemeral resource, "+
+				"or configure the provider with username and password instead of only a token.",
+		)
+		return
+	}
+
+	// Log in through the shared login path, with the TLS, proxy, header and throttling settings of the provider
+	client, err := newLeostreamClient(ctx, r.data.host, username, password, "", r.data.transport)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Open Leostream Session",
+			"Leostream Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	config.Token = types.StringValue(client.Token)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, config)...)
+
+	tflog.Debug(ctx, "Opened Leostream session", map[string]any{"username": username})
+}
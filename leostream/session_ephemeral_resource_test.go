@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testAccEchoProviderFactories adds the echo provider, which copies the token of
+// the ephemeral session into the state so the test can check it.
+var testAccEchoProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"leostream": testAccProtoV6ProviderFactories["leostream"],
+	"echo":      echoprovider.NewProviderServer(),
+}
+
+func TestAccSessionEphemeralResource(t *testing.T) {
+	broker := newTestBroker(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccEchoProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: broker.providerConfig() + `
+ephemeral "leostream_session" "test" {
+  password = "wrong"
+}
+
+provider "echo" {
+  data = ephemeral.leostream_session.test.token
+}
+
+resource "echo" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Unable to Open Leostream Session`),
+			},
+			{
+				Config: broker.providerConfig() + `
+ephemeral "leostream_session" "test" {}
+
+provider "echo" {
+  data = ephemeral.leostream_session.test.token
+}
+
+resource "echo" "test" {}
+`,
+				Check: testAccCheckSessionOpen(broker, "echo.test"),
+			},
+			{
+				Config: broker.providerConfig() + fmt.Sprintf(`
+ephemeral "leostream_session" "test" {
+  username = %q
+  password = %q
+}
+
+provider "echo" {
+  data = ephemeral.leostream_session.test.token
+}
+
+resource "echo" "test" {}
+`, broker.username, broker.password),
+				Check: testAccCheckSessionOpen(broker, "echo.test"),
+			},
+		},
+	})
+}
+
+// testAccCheckSessionOpen checks that the echoed token is an open session of the broker.
+func testAccCheckSessionOpen(broker *testBroker, address string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[address]
+		if !ok {
+			return fmt.Errorf("%s not found in state", address)
+		}
+
+		token := rs.Primary.Attributes["data"]
+		if !strings.HasPrefix(token, "test-session-") {
+			return fmt.Errorf("expected a session token of the broker, got %q", token)
+		}
+
+		broker.mu.Lock()
+		defer broker.mu.Unlock()
+		if !broker.sessions[token] {
+			return fmt.Errorf("session %s is not open on the broker", token)
+		}
+		return nil
+	}
+}
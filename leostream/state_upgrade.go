@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// stateMigration reshapes the raw JSON state of a resource from one schema
+// version to the next one.
+type stateMigration func(state map[string]any) error
+
+// State migrations per resource, the migration at index v upgrades schema
+// version v to v+1. The current schema version of a resource is the number of
+// its migrations, so a new migration is added by appending it to the list.
+var (
+	poolStateMigrations = []stateMigration{
+		// version 0 used 0/1 integers for the flag attributes
+		flagsToBool(poolFlagAttributes),
+	}
+	centerStateMigrations = []stateMigration{
+		// version 0 used 0/1 integers for the flag attributes
+		flagsToBool(centerFlagAttributes),
+	}
+	gatewayStateMigrations = []stateMigration{}
+)
+
+// Flag attributes that were 0/1 integers before schema version 1 and are booleans since.
+var (
+	poolFlagAttributes = [][]string{
+		{"pool_definition", "never_rogue"},
+		{"pool_definition", "use_vmotion"},
+		{"provision", "provision_on_off"},
+		{"provision", "provision_limits_enforce"},
+		{"provision", "mark_deletable"},
+	}
+	centerFlagAttributes = [][]string{
+		{"center_definition", "allow_rogue"},
+		{"center_definition", "continuous_autotag"},
+		{"center_definition", "init_unavailable"},
+		{"center_definition", "new_as_deletable"},
+		{"center_definition", "offer_vms"},
+		{"center_definition", "wait_inst_status"},
+		{"center_definition", "wait_sys_status"},
+	}
+)
+
+// schemaVersion returns the current schema version for the given migrations.
+func schemaVersion(migrations []stateMigration) int64 {
+	return int64(len(migrations))
+}
+
+// stateUpgraders returns a state upgrader for every prior schema version. The
+// state of a prior version is passed through all later migrations, so it is
+// upgraded straight to the current version as the framework requires.
+//
+// The upgraders work on the raw JSON state, so the schemas of prior versions do
+// not have to be kept around.
+func stateUpgraders(migrations []stateMigration) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(migrations))
+
+	for version := range migrations {
+		from := int64(version)
+		upgraders[from] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil || req.RawState.JSON == nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("The resource state of schema version %d is not available as JSON, which is required to upgrade it.", from),
+					)
+					return
+				}
+
+				upgraded, err := upgradeRawState(req.RawState.JSON, migrations[from:])
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Could not upgrade the resource state from schema version %d to %d: %s", from, schemaVersion(migrations), err),
+					)
+					return
+				}
+
+				tflog.Debug(ctx, "Upgraded resource state", map[string]any{
+					"from_version": from,
+					"to_version":   schemaVersion(migrations),
+				})
+
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+			},
+		}
+	}
+
+	return upgraders
+}
+
+// upgradeRawState applies the migrations in order to a JSON state.
+func upgradeRawState(state []byte, migrations []stateMigration) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(state))
+	decoder.UseNumber()
+
+	var raw map[string]any
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	for _, migrate := range migrations {
+		if err := migrate(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(raw)
+}
+
+// flagsToBool returns a migration that replaces the numbers at the given paths
+// with booleans, any value other than 0 is true. Null values and missing
+// objects stay as they are.
+func flagsToBool(flags [][]string) stateMigration {
+	return func(state map[string]any) error {
+		for _, flag := range flags {
+			parent := state
+			for _, name := range flag[:len(flag)-1] {
+				parent, _ = parent[name].(map[string]any)
+				if parent == nil {
+					break
+				}
+			}
+			if parent == nil {
+				continue
+			}
+
+			name := flag[len(flag)-1]
+			switch value := parent[name].(type) {
+			case nil, bool:
+				// null, or already converted
+			case json.Number:
+				number, err := value.Float64()
+				if err != nil {
+					return fmt.Errorf("%s: %w", strings.Join(flag, "."), err)
+				}
+				parent[name] = number != 0
+			default:
+				return fmt.Errorf("%s: expected a number, got %T", strings.Join(flag, "."), value)
+			}
+		}
+
+		return nil
+	}
+}
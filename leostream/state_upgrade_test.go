@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// upgradeFixtureState upgrades the fixture state of the given schema version to
+// the current schema of the resource, the way Terraform does on the next plan.
+func upgradeFixtureState(t *testing.T, r resource.Resource, fixture string, version int64) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	raw, err := os.ReadFile(filepath.Join("testdata", "state", fixture))
+	if err != nil {
+		t.Fatalf("reading fixture: %s", err)
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("schema: %v", schemaResp.Diagnostics)
+	}
+	s := schemaResp.Schema
+	stateType := s.Type().TerraformType(ctx)
+
+	if version == s.Version {
+		value, err := (&tfprotov6.RawState{JSON: raw}).Unmarshal(stateType)
+		if err != nil {
+			t.Fatalf("decoding current version state: %s", err)
+		}
+		return tfsdk.State{Raw: value, Schema: s}
+	}
+
+	upgrader, ok := r.(resource.ResourceWithUpgradeState).UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no state upgrader for schema version %d", version)
+	}
+
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: raw}}
+	resp := resource.UpgradeStateResponse{}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrading state: %v", resp.Diagnostics)
+	}
+	if resp.DynamicValue == nil {
+		t.Fatal("upgrader returned no state")
+	}
+
+	value, err := resp.DynamicValue.Unmarshal(stateType)
+	if err != nil {
+		t.Fatalf("decoding upgraded state: %s", err)
+	}
+
+	return tfsdk.State{Raw: value, Schema: s}
+}
+
+func TestUpgradeStateFixtures(t *testing.T) {
+	tests := map[string]struct {
+		resource resource.Resource
+		fixture  string
+		version  int64
+		model    any
+		want     map[string]bool
+	}{
+		"aws_pool_v0": {
+			resource: NewAwsPoolResource(),
+			fixture:  "aws_pool_v0.json",
+			model:    &poolResourceModel{},
+			want: map[string]bool{
+				"pool_definition.never_rogue":        true,
+				"pool_definition.use_vmotion":        false,
+				"provision.provision_on_off":         true,
+				"provision.provision_limits_enforce": false,
+				"provision.mark_deletable":           true,
+			},
+		},
+		"basic_pool_v0": {
+			resource: NewBasicPoolResource(),
+			fixture:  "basic_pool_v0.json",
+			model:    &poolResourceModel{},
+			want: map[string]bool{
+				"pool_definition.never_rogue":        false,
+				"pool_definition.use_vmotion":        true,
+				"provision.provision_on_off":         false,
+				"provision.provision_limits_enforce": true,
+				"provision.mark_deletable":           false,
+			},
+		},
+		"center_v0": {
+			resource: NewCenterResource(),
+			fixture:  "center_v0.json",
+			model:    &centerResourceModel{},
+			want: map[string]bool{
+				"center_definition.allow_rogue":        false,
+				"center_definition.continuous_autotag": true,
+				"center_definition.init_unavailable":   false,
+				"center_definition.new_as_deletable":   true,
+				"center_definition.offer_vms":          true,
+				"center_definition.wait_inst_status":   true,
+				"center_definition.wait_sys_status":    false,
+			},
+		},
+		"gateway_v0": {
+			resource: NewGatewayResource(),
+			fixture:  "gateway_v0.json",
+			model:    &gatewayResourceModel{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			state := upgradeFixtureState(t, test.resource, test.fixture, test.version)
+
+			// The upgraded state must decode into the current resource model
+			if diags := state.Get(ctx, test.model); diags.HasError() {
+				t.Fatalf("decoding upgraded state into the model: %v", diags)
+			}
+
+			for attribute, want := range test.want {
+				var got types.Bool
+				if diags := state.GetAttribute(ctx, attributePath(attribute), &got); diags.HasError() {
+					t.Fatalf("%s: %v", attribute, diags)
+				}
+				if got.IsNull() || got.ValueBool() != want {
+					t.Errorf("%s: got %s, want %t", attribute, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUpgradeStateVersions(t *testing.T) {
+	ctx := context.Background()
+
+	for name, r := range map[string]resource.Resource{
+		"aws_pool":   NewAwsPoolResource(),
+		"basic_pool": NewBasicPoolResource(),
+		"center":     NewCenterResource(),
+		"gateway":    NewGatewayResource(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			upgraders := r.(resource.ResourceWithUpgradeState).UpgradeState(ctx)
+			if int64(len(upgraders)) != schemaResp.Schema.Version {
+				t.Fatalf("got %d state upgraders for schema version %d", len(upgraders), schemaResp.Schema.Version)
+			}
+			for version := int64(0); version < schemaResp.Schema.Version; version++ {
+				if _, ok := upgraders[version]; !ok {
+					t.Errorf("no state upgrader for schema version %d", version)
+				}
+			}
+		})
+	}
+}
+
+func TestFlagsToBool(t *testing.T) {
+	flags := [][]string{{"definition", "flag"}}
+
+	tests := map[string]struct {
+		state   string
+		want    string
+		wantErr bool
+	}{
+		"one":            {state: `{"definition":{"flag":1}}`, want: `{"definition":{"flag":true}}`},
+		"zero":           {state: `{"definition":{"flag":0}}`, want: `{"definition":{"flag":false}}`},
+		"null":           {state: `{"definition":{"flag":null}}`, want: `{"definition":{"flag":null}}`},
+		"already bool":   {state: `{"definition":{"flag":true}}`, want: `{"definition":{"flag":true}}`},
+		"missing object": {state: `{"definition":null}`, want: `{"definition":null}`},
+		"other numbers":  {state: `{"definition":{"flag":1,"other":12345678901234567}}`, want: `{"definition":{"flag":true,"other":12345678901234567}}`},
+		"string":         {state: `{"definition":{"flag":"1"}}`, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := upgradeRawState([]byte(test.state), []stateMigration{flagsToBool(flags)})
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+// attributePath converts a dotted attribute name into a path.
+func attributePath(attribute string) path.Path {
+	names := strings.Split(attribute, ".")
+	p := path.Root(names[0])
+	for _, name := range names[1:] {
+		p = p.AtName(name)
+	}
+	return p
+}
@@ -3,7 +3,15 @@
 package leostream
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -16,3 +24,101 @@ func convertToAttrInt64(elems []int64) []attr.Value {
 	}
 	return output
 }
+
+// boolToInt64 converts a boolean attribute to the 0 or 1 flag used by the Leostream API
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// stringValueOrEnv returns the configured value, or the environment variable if the attribute is not set
+func stringValueOrEnv(value types.String, env string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+	return os.Getenv(env)
+}
+
+// headersValueOrEnv returns the configured headers, or the headers of the environment variable if the attribute
+// is not set. The environment variable is a comma separated list of Name=value pairs.
+func headersValueOrEnv(ctx context.Context, value types.Map, env string, diags *diag.Diagnostics) (http.Header, error) {
+	headers := http.Header{}
+	if !value.IsNull() && !value.IsUnknown() {
+		var values map[string]string
+		diags.Append(value.ElementsAs(ctx, &values, false)...)
+		for name, v := range values {
+			headers.Set(name, v)
+		}
+		return headers, nil
+	}
+
+	if v := os.Getenv(env); v != "" {
+		for i, header := range strings.Split(v, ",") {
+			name, v, found := strings.Cut(header, "=")
+			if !found || strings.TrimSpace(name) == "" {
+				// the header itself is not included, it may hold a secret
+				return nil, fmt.Errorf("entry %d is not a Name=value pair", i+1)
+			}
+			headers.Set(strings.TrimSpace(name), strings.TrimSpace(v))
+		}
+	}
+	return headers, nil
+}
+
+// stringValueOrDeprecatedEnv returns the configured value, or the environment variable if the attribute is not
+// set, or else the deprecated environment variable with a warning.
+func stringValueOrDeprecatedEnv(value types.String, env string, deprecated string, diags *diag.Diagnostics) string {
+	v := stringValueOrEnv(value, env)
+	if value.IsNull() && v == "" {
+		v = deprecatedEnv(diags, deprecated, env)
+	}
+	return v
+}
+
+// boolValueOrEnv returns the configured value, or the parsed environment variable if the attribute is not set
+func boolValueOrEnv(value types.Bool, env string) (bool, error) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueBool(), nil
+	}
+	if v := os.Getenv(env); v != "" {
+		return strconv.ParseBool(v)
+	}
+	return false, nil
+}
+
+// float64ValueOrEnv returns the configured value, or the parsed environment variable if the attribute is not set
+func float64ValueOrEnv(value types.Float64, env string) (float64, error) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueFloat64(), nil
+	}
+	if v := os.Getenv(env); v != "" {
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, nil
+}
+
+// int64ValueOrEnv returns the configured value, or the parsed environment variable if the attribute is not set
+func int64ValueOrEnv(value types.Int64, env string) (int64, error) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64(), nil
+	}
+	if v := os.Getenv(env); v != "" {
+		return strconv.ParseInt(v, 10, 64)
+	}
+	return 0, nil
+}
+
+// deprecatedEnv returns the value of a deprecated environment variable and adds a warning when it is used
+func deprecatedEnv(diags *diag.Diagnostics, env string, replacement string) string {
+	v := os.Getenv(env)
+	if v != "" {
+		diags.AddWarning(
+			"Deprecated Environment Variable",
+			"The "+env+" environment variable is deprecated and will be removed in a future version of the provider. "+
+				"Use the "+replacement+" environment variable instead.",
+		)
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// awsCenterFields are the provision center fields that only apply to Amazon centers
+var awsCenterFields = []string{"aws_size", "aws_iam_name", "aws_sub_net", "aws_sec_group", "aws_vpc_id"}
+
+// validatePoolAttributes requires pool attributes when the configured restrict_by selects desktops by attribute.
+func validatePoolAttributes(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var restrictBy types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("pool_definition").AtName("restrict_by"), &restrictBy)...)
+
+	var attributes types.List
+	diags.Append(config.GetAttribute(ctx, path.Root("pool_definition").AtName("attributes"), &attributes)...)
+
+	if diags.HasError() || restrictBy.IsNull() || restrictBy.IsUnknown() || attributes.IsUnknown() {
+		return
+	}
+
+	if slices.Contains(CONFIG_POOL_RESTRICT_BY_ATTRIBUTES, restrictBy.ValueString()) && len(attributes.Elements()) == 0 {
+		diags.AddAttributeError(
+			path.Root("pool_definition").AtName("attributes"),
+			"Missing Pool Attributes",
+			"At least one entry in attributes is required when restrict_by is "+restrictBy.String()+".",
+		)
+	}
+}
+
+// validateAwsCenterFields rejects aws_* fields in the provision center of a pool when the center is not an Amazon center.
+func validateAwsCenterFields(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	centerPath := path.Root("provision").AtName("center")
+
+	var centerType types.String
+	diags.Append(config.GetAttribute(ctx, centerPath.AtName("type"), &centerType)...)
+	if diags.HasError() || centerType.IsNull() || centerType.IsUnknown() {
+		return
+	}
+
+	if centerType.ValueString() == "" || centerType.ValueString() == CONFIG_CENTER_TYPE_AMAZON {
+		return
+	}
+
+	for _, field := range awsCenterFields {
+		var value types.String
+		diags.Append(config.GetAttribute(ctx, centerPath.AtName(field), &value)...)
+		if diags.HasError() {
+			return
+		}
+
+		if !value.IsNull() && !value.IsUnknown() && value.ValueString() != "" {
+			diags.AddAttributeError(
+				centerPath.AtName(field),
+				"Invalid Attribute Combination",
+				field+" can only be set for centers of type "+CONFIG_CENTER_TYPE_AMAZON+", got center type "+centerType.String()+". "+
+					"Remove "+strings.Join(awsCenterFields, ", ")+" from the center block.",
+			)
+		}
+	}
+}
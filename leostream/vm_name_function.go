@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &vmNameFunction{}
+)
+
+// vmNamePartRegexp matches the text around the sequence placeholder of a VM name.
+var vmNamePartRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]*$`)
+
+// NewVmNameFunction is a helper function to simplify the provider implementation.
+func NewVmNameFunction() function.Function {
+	return &vmNameFunction{}
+}
+
+// vmNameFunction is the function implementation.
+type vmNameFunction struct{}
+
+// Metadata returns the function name.
+func (f *vmNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "vm_name"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *vmNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a provision_vm_name template",
+		Description: "Returns a template for the provision_vm_name of a pool, with the " + CONFIG_POOL_VM_NAME_SEQUENCE + " placeholder between the prefix and the suffix. " +
+			"The prefix and suffix may only contain letters, digits, dots, hyphens and underscores, so the template holds exactly one placeholder.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "prefix",
+				Description: "The text before the sequence number, e.g. \"desktop-\".",
+			},
+			function.StringParameter{
+				Name:        "suffix",
+				Description: "The text after the sequence number, may be empty.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run returns the VM name template.
+func (f *vmNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var prefix, suffix string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &prefix, &suffix))
+	if resp.Error != nil {
+		return
+	}
+
+	name, err := vmName(prefix, suffix)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, err)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, name))
+}
+
+// vmName checks the arguments of the vm_name function and returns the template.
+func vmName(prefix, suffix string) (string, *function.FuncError) {
+	for position, part := range []string{prefix, suffix} {
+		if !vmNamePartRegexp.MatchString(part) {
+			return "", function.NewArgumentFuncError(int64(position),
+				"Invalid VM name part "+strconv.Quote(part)+", only letters, digits, dots, hyphens and underscores are allowed "+
+					"around the "+CONFIG_POOL_VM_NAME_SEQUENCE+" placeholder.")
+		}
+	}
+
+	if prefix == "" && suffix == "" {
+		return "", function.NewFuncError("A VM name needs a prefix or a suffix, " + CONFIG_POOL_VM_NAME_SEQUENCE + " alone is not a usable name.")
+	}
+
+	return prefix + CONFIG_POOL_VM_NAME_SEQUENCE + suffix, nil
+}
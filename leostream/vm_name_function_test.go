@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+
+package leostream
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestVmNameFunctionRun(t *testing.T) {
+	tests := map[string]struct {
+		prefix, suffix string
+		want           string
+		wantError      bool
+	}{
+		"prefix":            {prefix: "desktop-", want: "desktop-{SEQUENCE}"},
+		"prefix and suffix": {prefix: "desktop-", suffix: ".corp", want: "desktop-{SEQUENCE}.corp"},
+		"suffix":            {suffix: "-desktop", want: "{SEQUENCE}-desktop"},
+		"placeholder":       {prefix: "desktop-{SEQUENCE}", wantError: true},
+		"space":             {prefix: "desktop ", wantError: true},
+		"invalid suffix":    {prefix: "desktop-", suffix: "/1", wantError: true},
+		"empty":             {wantError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := runFunction(&vmNameFunction{}, types.StringUnknown(), types.StringValue(test.prefix), types.StringValue(test.suffix))
+
+			if test.wantError {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %v", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(test.want)) {
+				t.Errorf("got %s, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAccVmNameFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "vm_name" {
+  value = provider::leostream::vm_name("desktop-", "")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("vm_name", knownvalue.StringExact("desktop-{SEQUENCE}")),
+				},
+			},
+			{
+				Config: `
+output "vm_name" {
+  value = provider::leostream::vm_name("desktop-{SEQUENCE}", "")
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid VM name part`),
+			},
+		},
+	})
+}